@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"time"
+
+	"github.com/young1lin/claude-token-monitor/internal/statusline/content"
+)
+
+// usageText is printed to stderr when the binary is run by hand: stdin is a
+// terminal or carries no payload.
+const usageText = `statusline renders a Claude Code status line from the JSON Claude Code
+writes to stdin. It is meant to be run by Claude Code, e.g. in
+~/.claude/settings.json:
+
+  "statusLine": {"type": "command", "command": "/path/to/statusline"}
+
+Usage:
+  statusline [flags] < payload.json
+
+Flags:
+  --demo          render with sample data to preview your config
+  --once          render once for a minimal session (model "Claude", no
+                  tokens) to check git, memory and other local segments
+  --cwd DIR       directory for --demo / --once (default: current dir)
+  --format json   emit a single-line JSON object instead of text
+  --proxy URL     route api.anthropic.com requests through a proxy
+  --debug         append the raw stdin payload to statusline.debug
+  --version       print version information
+`
+
+// isTerminal reports whether r is an interactive terminal, i.e. nobody is
+// piping a payload in and reading it would block on the keyboard.
+func isTerminal(r io.Reader) bool {
+	f, ok := r.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// demoInput returns a sample StatusLineInput payload for --demo, rooted at
+// cwd so the git and memory segments reflect the real directory. Rate
+// limits are included so the quota segment renders without any API call.
+func demoInput(cwd string) []byte {
+	var in content.StatusLineInput
+	in.SessionID = "demo"
+	in.Cwd = cwd
+	in.Workspace.CurrentDir = cwd
+	in.Workspace.ProjectDir = cwd
+	in.Version = version
+	in.Model.ID = "claude-sonnet-4-5"
+	in.Model.DisplayName = "Sonnet 4.5"
+	in.ContextWindow.ContextWindowSize = 200_000
+	in.ContextWindow.TotalInputTokens = 412_000
+	in.ContextWindow.TotalOutputTokens = 38_500
+	in.ContextWindow.CurrentUsage.InputTokens = 6_200
+	in.ContextWindow.CurrentUsage.OutputTokens = 1_400
+	in.ContextWindow.CurrentUsage.CacheReadInputTokens = 71_000
+	in.ContextWindow.CurrentUsage.CacheCreationInputTokens = 4_800
+	in.Cost.TotalCostUSD = 1.27
+	in.Cost.TotalDurationMs = int((42 * time.Minute).Milliseconds())
+	in.Cost.TotalAPIDurationMs = int((9 * time.Minute).Milliseconds())
+	in.Cost.TotalLinesAdded = 214
+	in.Cost.TotalLinesRemoved = 37
+	now := time.Now()
+	in.RateLimits = &content.StdinRateLimits{
+		FiveHour: &content.StdinRateLimitWindow{UsedPercentage: 42, ResetsAt: now.Add(2*time.Hour + 15*time.Minute).Unix()},
+		SevenDay: &content.StdinRateLimitWindow{UsedPercentage: 18, ResetsAt: now.Add(3 * 24 * time.Hour).Unix()},
+	}
+
+	data, _ := json.Marshal(in)
+	return data
+}
+
+// onceInput returns the minimal payload for --once: model "Claude", no token
+// usage, rooted at cwd. Only segments that read local state (git, memory
+// files, time) have anything to show.
+func onceInput(cwd string) []byte {
+	var in content.StatusLineInput
+	in.Cwd = cwd
+	in.Workspace.CurrentDir = cwd
+	in.Workspace.ProjectDir = cwd
+	in.Model.DisplayName = "Claude"
+
+	data, _ := json.Marshal(in)
+	return data
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/young1lin/claude-token-monitor/internal/statusline/content"
+	"github.com/young1lin/claude-token-monitor/internal/statusline/layout"
+)
+
+// outputFormatJSON selects the machine-readable output mode, via the
+// --format json flag or STATUSLINE_OUTPUT=json (STATUSLINE_FORMAT=json is
+// accepted as an alias).
+const outputFormatJSON = "json"
+
+// jsonOutput is the stable schema emitted in JSON output mode. Every key is
+// always present so scripts can index fields without existence checks;
+// missing data shows up as "", 0 or null. Text values are ANSI-stripped.
+// display.show / display.hide shape the text layout only and are ignored here.
+// Add fields at the end and never rename existing ones — the golden file in
+// testdata pins the shape.
+type jsonOutput struct {
+	Project            string          `json:"project"`
+	Model              string          `json:"model"`
+	ModelID            string          `json:"model_id"`
+	Tokens             int             `json:"tokens"`
+	MaxTokens          int             `json:"max_tokens"`
+	Pct                float64         `json:"pct"`
+	GitBranch          string          `json:"git_branch"`
+	GitStatus          string          `json:"git_status"`
+	GitRemote          string          `json:"git_remote"`
+	MemoryFiles        string          `json:"memory_files"`
+	ToolsCount         int             `json:"tools_count"`
+	Agent              string          `json:"agent"`
+	TodoCompleted      int             `json:"todo_completed"`
+	TodoTotal          int             `json:"todo_total"`
+	SessionDurationSec int             `json:"session_duration_sec"`
+	CostUSD            float64         `json:"cost_usd"`
+	Quota              string          `json:"quota"`
+	RateLimits         *jsonRateLimits `json:"rate_limits"`
+	ToolsFailed        int             `json:"tools_failed"`
+	ToolsUsed          int             `json:"tools_used"`
+	Cost               string          `json:"cost"`
+	SessionDuration    string          `json:"session_duration"`
+}
+
+// jsonRateLimits carries the host-reported quota windows. Null when Claude
+// Code didn't send rate_limits on stdin.
+type jsonRateLimits struct {
+	FiveHour *jsonRateWindow `json:"five_hour"`
+	SevenDay *jsonRateWindow `json:"seven_day"`
+}
+
+// jsonRateWindow is one quota window: utilization and its RFC3339 reset time.
+type jsonRateWindow struct {
+	UsedPct  float64 `json:"used_pct"`
+	ResetsAt string  `json:"resets_at"`
+}
+
+// outputFormat resolves the requested output format. The --format flag wins
+// over STATUSLINE_OUTPUT, which wins over its STATUSLINE_FORMAT alias; text
+// is the default.
+func outputFormat(flagValue string) string {
+	for _, v := range []string{flagValue, os.Getenv("STATUSLINE_OUTPUT"), os.Getenv("STATUSLINE_FORMAT")} {
+		if v = strings.ToLower(strings.TrimSpace(v)); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// buildJSONOutput assembles the JSON view from the same inputs the text
+// renderer uses. contentMap must be the raw composer output, before the
+// folder / version display prefixes are applied.
+func buildJSONOutput(input *content.StatusLineInput, summary *content.TranscriptSummary, contentMap layout.CellContent) jsonOutput {
+	tokens, maxTokens := content.ContextTokens(input, summary)
+	text := func(key string) string {
+		return layout.StripANSI(contentMap[key])
+	}
+
+	out := jsonOutput{
+		Project:       text(string(content.ContentFolder)),
+		Model:         text(string(content.ContentModel)),
+		ModelID:       input.Model.ID,
+		Tokens:        tokens,
+		MaxTokens:     maxTokens,
+		Pct:           math.Round(float64(tokens)/float64(maxTokens)*1000) / 10,
+		GitBranch:     text(string(content.ContentGitBranch)),
+		GitStatus:     text(string(content.ContentGitStatus)),
+		GitRemote:     text(string(content.ContentGitRemote)),
+		MemoryFiles:   text(string(content.ContentMemoryFiles)),
+		Agent:         text(string(content.ContentAgent)),
+		TodoCompleted: summary.TodoCompleted,
+		TodoTotal:     summary.TodoTotal,
+		CostUSD:       input.Cost.TotalCostUSD,
+		Quota:         text(string(content.ContentQuota)),
+	}
+
+	// tools_count is every finished call, as in the text tools cell;
+	// tools_failed is the errored share of it.
+	for _, count := range summary.CompletedTools {
+		out.ToolsCount += count
+	}
+	for _, count := range summary.FailedTools {
+		out.ToolsFailed += count
+	}
+	out.ToolsCount += out.ToolsFailed
+	out.ToolsUsed = out.ToolsCount
+
+	// cost and session_duration are the formatted counterparts of cost_usd
+	// and session_duration_sec, e.g. "$1.25" and "1h30m", without icons.
+	if input.Cost.TotalCostUSD > 0 {
+		out.Cost = content.FormatCost(input.Cost.TotalCostUSD)
+	}
+
+	if !summary.SessionStart.IsZero() && !summary.SessionEnd.IsZero() {
+		duration := summary.SessionEnd.Sub(summary.SessionStart)
+		out.SessionDurationSec = int(duration.Seconds())
+		out.SessionDuration = content.FormatDuration(duration)
+	}
+
+	if rl := input.RateLimits; rl != nil {
+		out.RateLimits = &jsonRateLimits{
+			FiveHour: toJSONRateWindow(rl.FiveHour),
+			SevenDay: toJSONRateWindow(rl.SevenDay),
+		}
+	}
+
+	return out
+}
+
+func toJSONRateWindow(w *content.StdinRateLimitWindow) *jsonRateWindow {
+	if w == nil {
+		return nil
+	}
+	out := &jsonRateWindow{UsedPct: w.UsedPercentage}
+	if w.ResetsAt > 0 {
+		out.ResetsAt = time.Unix(w.ResetsAt, 0).UTC().Format(time.RFC3339)
+	}
+	return out
+}
+
+// writeJSONOutput writes out as a single line of JSON.
+func writeJSONOutput(w io.Writer, out jsonOutput) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return enc.Encode(out)
+}
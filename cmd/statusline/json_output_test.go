@@ -0,0 +1,165 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/young1lin/claude-token-monitor/internal/statusline/content"
+	"github.com/young1lin/claude-token-monitor/internal/statusline/layout"
+)
+
+// goldenJSONPath pins the JSON output schema. Regenerate deliberately, by
+// hand, only when adding fields — renames break downstream scripts.
+var goldenJSONPath = filepath.Join("testdata", "json_output.golden")
+
+// TestBuildJSONOutput_Golden verifies the JSON schema and values against the
+// golden file using fixed inputs (no git, network or clock involved).
+func TestBuildJSONOutput_Golden(t *testing.T) {
+	// Arrange
+	var input content.StatusLineInput
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"model": {"display_name": "Opus", "id": "claude-opus-4-1"},
+		"context_window": {"context_window_size": 200000,
+			"current_usage": {"input_tokens": 10000, "cache_read_input_tokens": 45000, "output_tokens": 5000}},
+		"cost": {"total_cost_usd": 1.25},
+		"rate_limits": {"five_hour": {"used_percentage": 42, "resets_at": 1777636800}}
+	}`), &input))
+	start := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	summary := &content.TranscriptSummary{
+		CompletedTools: map[string]int{"Read": 3, "Bash": 2},
+		TodoCompleted:  2,
+		TodoTotal:      5,
+		SessionStart:   start,
+		SessionEnd:     start.Add(90 * time.Minute),
+	}
+	contentMap := layout.CellContent{
+		"folder":       "myproject",
+		"model":        "Opus",
+		"git-branch":   "main",
+		"git-status":   "+1 ~2",
+		"memory-files": "📦 2 CLAUDE.md + 3 rules",
+		"agent":        "🤖 Explore",
+		"quota":        "📊 \x1b[1;32m42%\x1b[0m 5h",
+	}
+
+	// Act
+	var sb strings.Builder
+	require.NoError(t, writeJSONOutput(&sb, buildJSONOutput(&input, summary, contentMap)))
+
+	// Assert
+	golden, err := os.ReadFile(goldenJSONPath)
+	require.NoError(t, err)
+	assert.Equal(t, string(golden), sb.String())
+}
+
+// TestBuildJSONOutput_EmptyInput verifies every key is present and rate_limits
+// is null when nothing is known.
+func TestBuildJSONOutput_EmptyInput(t *testing.T) {
+	// Act
+	var sb strings.Builder
+	require.NoError(t, writeJSONOutput(&sb, buildJSONOutput(&content.StatusLineInput{}, &content.TranscriptSummary{}, layout.CellContent{})))
+
+	// Assert
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(sb.String()), &got))
+	assert.Len(t, got, 22)
+	assert.Nil(t, got["rate_limits"])
+	assert.Equal(t, float64(200000), got["max_tokens"])
+}
+
+// TestBuildJSONOutput_FailedTools verifies errored tool calls count towards
+// tools_count, as in the text tools cell, and are broken out in tools_failed.
+func TestBuildJSONOutput_FailedTools(t *testing.T) {
+	// Arrange
+	summary := &content.TranscriptSummary{
+		CompletedTools: map[string]int{"Read": 3, "Bash": 2},
+		FailedTools:    map[string]int{"Bash": 1, "WebFetch": 1},
+	}
+
+	// Act
+	got := buildJSONOutput(&content.StatusLineInput{}, summary, layout.CellContent{})
+
+	// Assert
+	assert.Equal(t, 7, got.ToolsCount)
+	assert.Equal(t, 2, got.ToolsFailed)
+}
+
+// TestRun_JSONOutputIgnoresDisplayHide verifies display.hide only trims the
+// text layout: JSON fields stay filled even when their cells are hidden.
+func TestRun_JSONOutputIgnoresDisplayHide(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".claude"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".claude", "statusline.yml"),
+		[]byte("display:\n  hide: [session-total, token, folder]\n"), 0644))
+	cwd, err := json.Marshal(dir)
+	require.NoError(t, err)
+	input := `{"cwd": ` + string(cwd) + `,
+		"model": {"id": "claude-sonnet-4-5", "display_name": "Sonnet 4.5"},
+		"cost": {"total_cost_usd": 1.0}}`
+
+	// Act
+	var stdout, stderr strings.Builder
+	run(strings.NewReader(input), &stdout, &stderr, []string{"statusline", "--format", "json"})
+
+	// Assert
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(stdout.String()), &got))
+	assert.Equal(t, filepath.Base(dir), got["project"])
+	assert.Equal(t, "Sonnet 4.5", got["model"])
+	assert.NotEmpty(t, got["cost"])
+}
+
+func TestOutputFormat(t *testing.T) {
+	tests := []struct {
+		name      string
+		flag      string
+		env       string
+		formatEnv string
+		want      string
+	}{
+		{"default is text", "", "", "", ""},
+		{"env selects json", "", "JSON", "", "json"},
+		{"flag wins over env", "text", "json", "", "text"},
+		{"STATUSLINE_FORMAT alias selects json", "", "", "json", "json"},
+		{"STATUSLINE_OUTPUT wins over alias", "", "text", "json", "text"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			t.Setenv("STATUSLINE_OUTPUT", tt.env)
+			t.Setenv("STATUSLINE_FORMAT", tt.formatEnv)
+
+			// Act
+			got := outputFormat(tt.flag)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestRun_JSONOutput verifies the flag switches run() to a single JSON line
+// while text stays the default.
+func TestRun_JSONOutput(t *testing.T) {
+	for _, args := range [][]string{
+		{"statusline", "--format", "json"},
+		{"statusline", "-format=json"},
+	} {
+		var stdout, stderr strings.Builder
+		run(strings.NewReader(minimalInput), &stdout, &stderr, args)
+
+		var got map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(stdout.String()), &got), "args %v", args)
+		assert.Equal(t, "myproject", got["project"])
+		assert.Equal(t, "$1.00", got["cost"])
+		assert.Equal(t, 1, strings.Count(stdout.String(), "\n"))
+	}
+}
@@ -17,12 +17,14 @@ import (
 	"github.com/young1lin/claude-token-monitor/internal/statusline/content/composers"
 	"github.com/young1lin/claude-token-monitor/internal/statusline/layout"
 	"github.com/young1lin/claude-token-monitor/internal/statusline/render"
+	"github.com/young1lin/claude-token-monitor/internal/textutil"
 )
 
 // Version information injected by ldflags during build
 var (
 	version = "dev"
 	commit  = "unknown"
+	date    = "unknown"
 )
 
 // currentOS allows tests to override runtime.GOOS for cross-platform coverage.
@@ -81,9 +83,9 @@ func main() {
 // run contains the actual statusline logic, separated from main() for testability.
 // It accepts stdin, stdout, stderr, and args as parameters so tests can inject buffers.
 func run(stdin io.Reader, stdout, stderr io.Writer, args []string) {
-	// Handle --version flag
-	if len(args) > 1 && (args[1] == "--version" || args[1] == "-v") {
-		fmt.Fprintf(stdout, "statusline version %s (commit: %s)\n", version, commit)
+	// Handle --version flag (and the "version" subcommand) before touching stdin
+	if len(args) > 1 && (args[1] == "--version" || args[1] == "-v" || args[1] == "version") {
+		fmt.Fprintf(stdout, "statusline version %s (commit: %s, built: %s)\n", version, commit, date)
 		return
 	}
 
@@ -91,33 +93,76 @@ func run(stdin io.Reader, stdout, stderr io.Writer, args []string) {
 	// the rest of the entrypoint already uses ad-hoc scanning and we want to
 	// stay friendly to unknown future flags rather than aborting on them.
 	debugMode := false
+	demoMode := false
+	onceMode := false
+	cwdCLI := ""
 	proxyCLI := ""
+	formatCLI := ""
 	for i, arg := range args {
 		switch {
 		case arg == "--debug":
 			debugMode = true
+		case arg == "--demo":
+			demoMode = true
+		case arg == "--once":
+			onceMode = true
+		case strings.HasPrefix(arg, "--cwd="):
+			cwdCLI = strings.TrimPrefix(arg, "--cwd=")
+		case arg == "--cwd" && i+1 < len(args):
+			cwdCLI = args[i+1]
 		case strings.HasPrefix(arg, "--proxy="):
 			proxyCLI = strings.TrimPrefix(arg, "--proxy=")
 		case arg == "--proxy" && i+1 < len(args):
 			// Tolerate the space-separated form (`--proxy URL`) too.
 			proxyCLI = args[i+1]
+		case strings.HasPrefix(arg, "--format="), strings.HasPrefix(arg, "-format="):
+			formatCLI = arg[strings.Index(arg, "=")+1:]
+		case (arg == "--format" || arg == "-format") && i+1 < len(args):
+			formatCLI = args[i+1]
 		}
 	}
 
 	// Initialize Windows console for UTF-8 and ANSI support
 	initConsole()
 
-	// Read all input from stdin
-	inputBytes, err := io.ReadAll(stdin)
-	if err != nil {
-		fmt.Fprintf(stderr, "Error reading stdin: %v\n", err)
-		return
-	}
+	var inputBytes []byte
+	if demoMode || onceMode {
+		// Synthesize the payload instead of reading stdin: sample data for
+		// previewing a config (--demo), or a bare session for checking the
+		// local segments of a directory (--once).
+		cwd := cwdCLI
+		if cwd == "" {
+			cwd, _ = os.Getwd()
+		} else if abs, err := filepath.Abs(cwd); err == nil {
+			cwd = abs
+		}
+		if demoMode {
+			inputBytes = demoInput(cwd)
+		} else {
+			inputBytes = onceInput(cwd)
+		}
+	} else {
+		// Run by hand with nothing piped in: explain instead of blocking on
+		// the keyboard or exiting silently.
+		if isTerminal(stdin) {
+			fmt.Fprint(stderr, usageText)
+			return
+		}
 
-	// Trim null bytes
-	inputBytes = trimNullBytes(inputBytes)
-	if len(inputBytes) == 0 {
-		return
+		// Read all input from stdin
+		var err error
+		inputBytes, err = io.ReadAll(stdin)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error reading stdin: %v\n", err)
+			return
+		}
+
+		// Trim null bytes
+		inputBytes = trimNullBytes(inputBytes)
+		if len(inputBytes) == 0 {
+			fmt.Fprint(stderr, usageText)
+			return
+		}
 	}
 
 	// Write debug file if --debug is enabled
@@ -190,6 +235,13 @@ func run(stdin io.Reader, stdout, stderr io.Writer, args []string) {
 	if input.TranscriptPath != "" {
 		parserSummary, _ := parser.ParseTranscriptLastNLines(input.TranscriptPath, 100)
 		if parserSummary != nil {
+			// Tool and agent totals span the whole session; everything else
+			// comes from the tail parse.
+			if full, err := parser.ParseTranscriptFull(input.TranscriptPath); err == nil {
+				parserSummary.CompletedTools = full.CompletedTools
+				parserSummary.FailedTools = full.FailedTools
+				parserSummary.Agents = full.Agents
+			}
 			summary = convertToContentSummary(parserSummary)
 		}
 	}
@@ -215,10 +267,47 @@ func run(stdin io.Reader, stdout, stderr io.Writer, args []string) {
 	// env > network.claudeAPIProxy YAML, all resolved in one place.
 	content.SetClaudeAPIProxy(cfg.ResolveClaudeAPIProxy(proxyCLI))
 	content.SetUsageCacheTTL(cfg.GetUsageCacheTTL())
+	content.SetShowCachedTokens(cfg.ShowCacheTokens())
+	content.SetCurrency(cfg.GetCurrency())
+	content.SetShowGitClean(cfg.ShowGitClean())
+	content.SetDurationCapDays(cfg.GetDurationCapDays())
+	content.SetTimeFormat(cfg.GetTimeFormat())
+	textutil.SetEllipsis(cfg.GetEllipsis())
+	content.SetShowDataAge(os.Getenv("STATUSLINE_DEBUG") == "1" || cfg.ShowDataAge())
+	content.SetShowApproval(cfg.ShowApproval())
+	content.SetTurnLimit(cfg.GetTurnLimit())
+	content.SetContextThresholds(cfg.GetThresholds())
+	content.SetBarWidth(cfg.GetBarWidth())
+	content.SetProgressBarStyle(cfg.GetProgressBarStyle())
+
+	// The turn count needs a full transcript scan, so only pay for it when a
+	// limit is configured and the counter will actually be shown.
+	if cfg.GetTurnLimit() > 0 && input.TranscriptPath != "" {
+		summary.UserTurns, _ = parser.CountUserTurns(input.TranscriptPath)
+	}
+
+	// Drop collectors hidden by display.show / display.hide before anything
+	// runs, so a hidden segment never shells out to git or hits the quota API.
+	// JSON output reports every field regardless of those lists, so nothing
+	// is pruned there.
+	jsonMode := outputFormat(formatCLI) == outputFormatJSON
+	applyContentOverrides(contentMgr, cfg)
+	gridLayout := layout.FilterLayout(layout.DefaultLayout(), cfg)
+	if !jsonMode {
+		pruneCollectors(contentMgr, gridLayout, cfg)
+	}
 
 	// Build content map using composers
 	contentMap := contentMgr.Compose(&input, summary)
 
+	// JSON output mode replaces the whole layout/render pipeline
+	if jsonMode {
+		if err := writeJSONOutput(stdout, buildJSONOutput(&input, summary, contentMap)); err != nil {
+			fmt.Fprintf(stderr, "JSON encode error: %v\n", err)
+		}
+		return
+	}
+
 	// Apply folder prefix
 	if folder, ok := contentMap["folder"]; ok && folder != "" {
 		contentMap["folder"] = "📁 " + folder
@@ -229,12 +318,11 @@ func run(stdin io.Reader, stdout, stderr io.Writer, args []string) {
 	}
 
 	// === Layer 2: Layout ===
-	defaultLayout := layout.DefaultLayout()
-	gridLayout := layout.FilterLayout(defaultLayout, cfg)
 	grid := layout.NewGrid(gridLayout, contentMap)
 
 	// === Layer 3: Render ===
 	tableRenderer := render.NewTableRenderer(grid)
+	tableRenderer.SetSeparator(cfg.GetSeparator())
 
 	// Check if single-line mode is enabled
 	// Environment variable takes precedence over config file
@@ -247,8 +335,13 @@ func run(stdin io.Reader, stdout, stderr io.Writer, args []string) {
 		lines = tableRenderer.Render()
 	}
 
-	// Print output
+	// Print output. NO_COLOR (any non-empty value, see no-color.org) strips
+	// every escape sequence, for logs and terminals without ANSI support.
+	noColor := os.Getenv("NO_COLOR") != ""
 	for _, line := range lines {
+		if noColor {
+			line = layout.StripANSI(line)
+		}
 		fmt.Fprintln(stdout, line)
 	}
 }
@@ -279,16 +372,51 @@ func convertToContentSummary(parserSummary *parser.TranscriptSummary) *content.T
 	}
 
 	return &content.TranscriptSummary{
-		GitBranch:      parserSummary.GitBranch,
-		GitStatus:      parserSummary.GitStatus,
-		ActiveTools:    parserSummary.ActiveTools,
-		CompletedTools: parserSummary.CompletedTools,
-		FailedTools:    parserSummary.FailedTools,
-		Agents:         agents,
-		TodoTotal:      parserSummary.TodoTotal,
-		TodoCompleted:  parserSummary.TodoCompleted,
-		SessionStart:   parserSummary.SessionStart,
-		SessionEnd:     parserSummary.SessionEnd,
+		GitBranch:        parserSummary.GitBranch,
+		GitStatus:        parserSummary.GitStatus,
+		ActiveTools:      parserSummary.ActiveTools,
+		CompletedTools:   parserSummary.CompletedTools,
+		FailedTools:      parserSummary.FailedTools,
+		Agents:           agents,
+		TodoTotal:        parserSummary.TodoTotal,
+		TodoCompleted:    parserSummary.TodoCompleted,
+		SessionStart:     parserSummary.SessionStart,
+		SessionEnd:       parserSummary.SessionEnd,
+		AwaitingApproval: parserSummary.AwaitingApproval,
+		ContextTokens:    parserSummary.ContextTokens,
+		Compactions:      parserSummary.Compactions,
+	}
+}
+
+// pruneCollectors unregisters collectors whose output cannot reach the screen
+// under the display show/hide lists. A collector is kept when its type is a
+// visible layout cell or an input of the composer behind one, unless it is
+// itself in the hide list — hide always wins. With no lists configured every
+// collector is kept.
+func pruneCollectors(mgr *content.Manager, visible *layout.Layout, cfg *config.Config) {
+	if len(cfg.Display.Show) == 0 && len(cfg.Display.Hide) == 0 {
+		return
+	}
+
+	needed := make(map[content.ContentType]bool)
+	for _, cell := range visible.Cells {
+		needed[content.ContentType(cell.ContentType)] = true
+		if composer, ok := mgr.GetComposer(cell.ContentType); ok {
+			for _, ct := range composer.InputTypes() {
+				needed[ct] = true
+			}
+		}
+	}
+
+	hidden := make(map[content.ContentType]bool)
+	for _, h := range cfg.Display.Hide {
+		hidden[content.ContentType(h)] = true
+	}
+
+	for _, ct := range mgr.Types() {
+		if !needed[ct] || hidden[ct] {
+			mgr.Unregister(ct)
+		}
 	}
 }
 
@@ -315,9 +443,32 @@ func registerAllCollectors(mgr *content.Manager) {
 		content.NewToolStatusDetailCollector(),
 		content.NewParentMemoryCollector(),
 		content.NewModeFlagsCollector(),
+		content.NewDataAgeCollector(),
+		content.NewApprovalCollector(),
+		content.NewTurnsCollector(),
+		content.NewOutputStyleCollector(),
+		content.NewCostCollector(),
 	)
 }
 
+// alternativeCollectors are opt-in collectors that can stand in for a default
+// one via content.use (e.g. `quota: quota-dual`). They are not registered
+// otherwise, so they never run — or hit a quota API — unless selected.
+var alternativeCollectors = map[string]func() content.ContentCollector{
+	string(content.ContentQuotaDual): func() content.ContentCollector { return content.NewQuotaDualCollector() },
+}
+
+// applyContentOverrides swaps in the alternative collectors selected under
+// content.use. Unknown names are ignored so a typo degrades to the default
+// rendering instead of an empty cell.
+func applyContentOverrides(mgr *content.Manager, cfg *config.Config) {
+	for contentType, name := range cfg.Content.Use {
+		if newCollector, ok := alternativeCollectors[name]; ok {
+			mgr.Replace(content.ContentType(contentType), newCollector())
+		}
+	}
+}
+
 // registerAllComposers registers all built-in composers
 func registerAllComposers(mgr *content.Manager) {
 	mgr.RegisterComposers(
@@ -4,12 +4,16 @@ import (
 	"encoding/json"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/young1lin/claude-token-monitor/internal/claudedir"
 	"github.com/young1lin/claude-token-monitor/internal/parser"
+	"github.com/young1lin/claude-token-monitor/internal/statusline/config"
 	"github.com/young1lin/claude-token-monitor/internal/statusline/content"
 	"github.com/young1lin/claude-token-monitor/internal/statusline/layout"
 )
@@ -93,58 +97,68 @@ func TestGitBranchInGitRepo(t *testing.T) {
 // TestTokenCalculation verifies token calculation logic
 func TestTokenCalculation(t *testing.T) {
 	tests := []struct {
-		name            string
-		inputTokens     int
-		outputTokens    int
-		cacheReadTokens int
-		expectedTotal   int
+		name                string
+		inputTokens         int
+		outputTokens        int
+		cacheReadTokens     int
+		cacheCreationTokens int
+		expectedTotal       int
 	}{
 		{
-			name:            "Only input tokens",
-			inputTokens:     10000,
-			outputTokens:    0,
-			cacheReadTokens: 0,
-			expectedTotal:   10000,
+			name:          "Only input tokens",
+			inputTokens:   10000,
+			expectedTotal: 10000,
 		},
 		{
-			name:            "Input + output",
-			inputTokens:     5000,
-			outputTokens:    2000,
-			cacheReadTokens: 0,
-			expectedTotal:   7000,
+			name:          "Input + output",
+			inputTokens:   5000,
+			outputTokens:  2000,
+			expectedTotal: 7000,
 		},
 		{
 			name:            "Input + cache read",
 			inputTokens:     3000,
-			outputTokens:    0,
 			cacheReadTokens: 5000,
 			expectedTotal:   8000,
 		},
 		{
-			name:            "All three types",
-			inputTokens:     5000,
-			outputTokens:    1000,
-			cacheReadTokens: 3000,
-			expectedTotal:   9000,
+			name:                "Input + cache creation",
+			inputTokens:         3000,
+			cacheCreationTokens: 40000,
+			expectedTotal:       43000,
 		},
 		{
-			name:            "Large numbers",
-			inputTokens:     150000,
-			outputTokens:    30000,
-			cacheReadTokens: 20000,
-			expectedTotal:   200000,
+			name:                "All four types",
+			inputTokens:         5000,
+			outputTokens:        1000,
+			cacheReadTokens:     3000,
+			cacheCreationTokens: 2000,
+			expectedTotal:       11000,
+		},
+		{
+			name:                "Large numbers",
+			inputTokens:         150000,
+			outputTokens:        30000,
+			cacheReadTokens:     20000,
+			cacheCreationTokens: 10000,
+			expectedTotal:       210000,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			total := tt.inputTokens + tt.cacheReadTokens + tt.outputTokens
+			input := &content.StatusLineInput{}
+			input.ContextWindow.ContextWindowSize = 200000
+			input.ContextWindow.CurrentUsage.InputTokens = tt.inputTokens
+			input.ContextWindow.CurrentUsage.OutputTokens = tt.outputTokens
+			input.ContextWindow.CurrentUsage.CacheReadInputTokens = tt.cacheReadTokens
+			input.ContextWindow.CurrentUsage.CacheCreationInputTokens = tt.cacheCreationTokens
+
+			total, contextWindow := content.ContextTokens(input, nil)
 			if total != tt.expectedTotal {
 				t.Errorf("Expected total %d, got %d", tt.expectedTotal, total)
 			}
 
-			// Test percentage calculation
-			contextWindow := 200000
 			pct := float64(total) / float64(contextWindow) * 100
 			t.Logf("Tokens: %d = %.1f%% of %d", total, pct, contextWindow)
 		})
@@ -166,6 +180,8 @@ func TestConvertToContentSummary(t *testing.T) {
 		},
 		TodoTotal:     10,
 		TodoCompleted: 5,
+		ContextTokens: 23500,
+		Compactions:   1,
 	}
 
 	result := convertToContentSummary(parserSummary)
@@ -188,6 +204,9 @@ func TestConvertToContentSummary(t *testing.T) {
 	if result.TodoCompleted != 5 {
 		t.Errorf("Expected TodoCompleted 5, got %d", result.TodoCompleted)
 	}
+	if result.ContextTokens != 23500 || result.Compactions != 1 {
+		t.Errorf("Expected ContextTokens 23500 and Compactions 1, got %d and %d", result.ContextTokens, result.Compactions)
+	}
 }
 
 // TestConvertToContentSummaryNilInput tests nil input handling
@@ -409,6 +428,97 @@ func TestRegisterAllComposers(t *testing.T) {
 	// If we get here, no panic occurred - all composers registered successfully
 }
 
+// TestApplyContentOverrides verifies content.use swaps collectors in place.
+func TestApplyContentOverrides(t *testing.T) {
+	// Arrange
+	mgr := content.NewManager()
+	registerAllCollectors(mgr)
+	before := len(mgr.Types())
+	cfg := config.DefaultConfig()
+	cfg.Content.Use = map[string]string{
+		"quota": "quota-dual",
+		"git":   "no-such-collector",
+	}
+
+	// Act
+	applyContentOverrides(mgr, cfg)
+
+	// Assert
+	assert.Len(t, mgr.Types(), before, "overrides replace collectors, they never add new types")
+	assert.NotContains(t, mgr.Types(), content.ContentQuotaDual)
+	assert.Contains(t, mgr.Types(), content.ContentQuota)
+}
+
+// TestPruneCollectors verifies display.show / display.hide remove collectors
+// that can never be rendered, so hidden segments are not even invoked.
+func TestPruneCollectors(t *testing.T) {
+	tests := []struct {
+		name     string
+		display  config.DisplayConfig
+		wantGone []content.ContentType
+		wantKept []content.ContentType
+		wantOnly []content.ContentType
+	}{
+		{
+			name:     "no lists keeps everything",
+			display:  config.DisplayConfig{},
+			wantKept: []content.ContentType{content.ContentTools, content.ContentSkills, content.ContentQuota},
+		},
+		{
+			name:     "hide-only drops hidden collectors",
+			display:  config.DisplayConfig{Hide: []string{"git-branch", "quota"}},
+			wantGone: []content.ContentType{content.ContentGitBranch, content.ContentQuota},
+			wantKept: []content.ContentType{content.ContentGitStatus, content.ContentCurrentTime, content.ContentFolder},
+		},
+		{
+			name:    "show-only keeps cells and their composer inputs",
+			display: config.DisplayConfig{Show: []string{"folder", "token"}},
+			wantOnly: []content.ContentType{
+				content.ContentFolder,
+				content.ContentModel,
+				content.ContentTokenBar,
+				content.ContentTokenInfo,
+				content.ContentModeFlags,
+			},
+		},
+		{
+			name:     "hide wins over show",
+			display:  config.DisplayConfig{Show: []string{"folder", "git"}, Hide: []string{"git"}},
+			wantOnly: []content.ContentType{content.ContentFolder},
+		},
+		{
+			name:     "hidden composer input is dropped even when the cell is shown",
+			display:  config.DisplayConfig{Show: []string{"git"}, Hide: []string{"git-remote"}},
+			wantOnly: []content.ContentType{content.ContentGitBranch, content.ContentGitStatus},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mgr := content.NewManager()
+			registerAllCollectors(mgr)
+			registerAllComposers(mgr)
+			cfg := &config.Config{Display: tt.display}
+
+			// Act
+			pruneCollectors(mgr, layout.FilterLayout(layout.DefaultLayout(), cfg), cfg)
+
+			// Assert
+			types := mgr.Types()
+			for _, ct := range tt.wantGone {
+				assert.NotContains(t, types, ct)
+			}
+			for _, ct := range tt.wantKept {
+				assert.Contains(t, types, ct)
+			}
+			if tt.wantOnly != nil {
+				assert.ElementsMatch(t, tt.wantOnly, types)
+			}
+		})
+	}
+}
+
 // ---------------------------------------------------------------------------
 // run() tests — call run() directly with buffers for full coverage
 // ---------------------------------------------------------------------------
@@ -460,6 +570,20 @@ func TestRun_ShortVersionFlag(t *testing.T) {
 	assert.Contains(t, stdout.String(), "statusline version")
 }
 
+// TestRun_VersionSubcommand verifies "version" prints version, commit and
+// build date without reading stdin — errorReader would surface on stderr.
+func TestRun_VersionSubcommand(t *testing.T) {
+	oldVersion, oldCommit, oldDate := version, commit, date
+	version, commit, date = "1.2.3", "abc1234", "2026-05-01T00:00:00Z"
+	t.Cleanup(func() { version, commit, date = oldVersion, oldCommit, oldDate })
+
+	var stdout, stderr strings.Builder
+	run(&errorReader{err: os.ErrClosed}, &stdout, &stderr, []string{"statusline", "version"})
+
+	assert.Empty(t, stderr.String())
+	assert.Equal(t, "statusline version 1.2.3 (commit: abc1234, built: 2026-05-01T00:00:00Z)\n", stdout.String())
+}
+
 func TestRun_ValidInput_SingleLine(t *testing.T) {
 	var stdout, stderr strings.Builder
 	run(strings.NewReader(minimalInput), &stdout, &stderr, []string{"statusline"})
@@ -481,17 +605,73 @@ func TestRun_ValidInput_MultiLine(t *testing.T) {
 func TestRun_EmptyInput(t *testing.T) {
 	var stdout, stderr strings.Builder
 	run(strings.NewReader(""), &stdout, &stderr, []string{"statusline"})
-	assert.Empty(t, stderr.String())
+	assert.Equal(t, usageText, stderr.String(), "empty input should explain how the binary is used")
 	assert.Empty(t, stdout.String())
 }
 
 func TestRun_OnlyNullBytes(t *testing.T) {
 	var stdout, stderr strings.Builder
 	run(strings.NewReader("\x00\x00\x00"), &stdout, &stderr, []string{"statusline"})
-	assert.Empty(t, stderr.String())
+	assert.Equal(t, usageText, stderr.String())
 	assert.Empty(t, stdout.String())
 }
 
+func TestRun_Demo(t *testing.T) {
+	t.Setenv("STATUSLINE_SINGLELINE", "")
+	t.Setenv("NO_COLOR", "1")
+
+	// stdin must not be read in demo mode
+	var stdout, stderr strings.Builder
+	run(&errorReader{err: os.ErrClosed}, &stdout, &stderr, []string{"statusline", "--demo"})
+
+	assert.Empty(t, stderr.String())
+	assert.Contains(t, stdout.String(), "Sonnet 4.5")
+	assert.Contains(t, stdout.String(), "83.4K/200K")
+}
+
+func TestRun_Once(t *testing.T) {
+	t.Setenv("STATUSLINE_SINGLELINE", "")
+	t.Setenv("NO_COLOR", "1")
+	dir := filepath.Join(t.TempDir(), "once-project")
+	require.NoError(t, os.Mkdir(dir, 0755))
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"separate cwd value", []string{"statusline", "--once", "--cwd", dir}},
+		{"cwd with equals", []string{"statusline", "--once", "--cwd=" + dir}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// stdin must not be read in --once mode
+			var stdout, stderr strings.Builder
+			run(&errorReader{err: os.ErrClosed}, &stdout, &stderr, tt.args)
+
+			assert.Empty(t, stderr.String())
+			assert.Contains(t, stdout.String(), "once-project")
+			assert.Contains(t, stdout.String(), "[Claude [⣀⣀⣀⣀⣀⣀⣀⣀⣀⣀] 0/200K (0.0%)]")
+		})
+	}
+}
+
+func TestIsTerminal(t *testing.T) {
+	// Neither a pipe, a regular file nor a non-file reader is a terminal.
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	defer r.Close()
+	defer w.Close()
+	assert.False(t, isTerminal(r))
+
+	f, err := os.CreateTemp(t.TempDir(), "payload")
+	require.NoError(t, err)
+	defer f.Close()
+	assert.False(t, isTerminal(f))
+
+	assert.False(t, isTerminal(strings.NewReader("{}")))
+}
+
 func TestRun_InvalidJSON(t *testing.T) {
 	var stdout, stderr strings.Builder
 	run(strings.NewReader("not json at all"), &stdout, &stderr, []string{"statusline"})
@@ -514,6 +694,74 @@ func TestRun_WithTranscriptPath(t *testing.T) {
 	assert.NotEmpty(t, stdout.String())
 }
 
+// TestRun_CompactionResetsContext verifies that right after a compaction,
+// when stdin carries no current_usage, the token cell shows the compacted
+// window rather than the transcript's pre-compaction peak.
+func TestRun_CompactionResetsContext(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	t.Setenv("STATUSLINE_SINGLELINE", "1")
+	transcript := filepath.Join(t.TempDir(), "session.jsonl")
+	require.NoError(t, os.WriteFile(transcript, []byte(
+		`{"type":"user","message":{"role":"user","content":"refactor it"},"timestamp":"2026-05-01T09:00:00Z"}`+"\n"+
+			`{"type":"assistant","message":{"role":"assistant","content":[],"usage":{"input_tokens":40,"cache_read_input_tokens":150000,"output_tokens":960}},"timestamp":"2026-05-01T09:04:00Z"}`+"\n"), 0644))
+	quoted, err := json.Marshal(transcript)
+	require.NoError(t, err)
+	input := strings.Replace(minimalInput, `"transcript_path": ""`, `"transcript_path": `+string(quoted), 1)
+	input = strings.Replace(input, `"current_usage": {
+      "input_tokens": 5000,
+      "output_tokens": 1000,
+      "cache_creation_input_tokens": 2000,
+      "cache_read_input_tokens": 3000
+    }`, `"current_usage": null`, 1)
+
+	render := func() string {
+		var stdout, stderr strings.Builder
+		run(strings.NewReader(input), &stdout, &stderr, []string{"statusline"})
+		require.Empty(t, stderr.String())
+		return stdout.String()
+	}
+
+	// Before compaction the transcript's last usage fills in for stdin
+	assert.Contains(t, render(), "151.0K/200K (75.5%)")
+
+	// After the summary entry the context restarts from the compacted window
+	f, err := os.OpenFile(transcript, os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"type":"summary","summary":"Refactored it","leafUuid":"x"}` + "\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	// Move the mtime on so the parser's in-process cache sees a new file
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(transcript, later, later))
+	out := render()
+	assert.Contains(t, out, "0/200K (0.0%)")
+	assert.NotContains(t, out, "151.0K")
+}
+
+// TestRun_ToolsCountSpansSession verifies the tool totals come from the
+// whole transcript, not just the current turn the tail parser scopes to.
+func TestRun_ToolsCountSpansSession(t *testing.T) {
+	transcript := filepath.Join(t.TempDir(), "session.jsonl")
+	require.NoError(t, os.WriteFile(transcript, []byte(
+		`{"type":"user","message":{"role":"user","content":"first"}}`+"\n"+
+			`{"type":"assistant","message":{"role":"assistant","content":[{"type":"tool_use","id":"t1","name":"Read","input":{}}]}}`+"\n"+
+			`{"type":"user","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t1"}]}}`+"\n"+
+			`{"type":"user","message":{"role":"user","content":"second"}}`+"\n"+
+			`{"type":"assistant","message":{"role":"assistant","content":[{"type":"tool_use","id":"t2","name":"Edit","input":{}}]}}`+"\n"+
+			`{"type":"user","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t2","is_error":true}]}}`+"\n"), 0644))
+	quoted, err := json.Marshal(transcript)
+	require.NoError(t, err)
+	input := strings.Replace(minimalInput, `"transcript_path": ""`, `"transcript_path": `+string(quoted), 1)
+
+	var stdout, stderr strings.Builder
+	run(strings.NewReader(input), &stdout, &stderr, []string{"statusline", "--format", "json"})
+
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(stdout.String()), &got))
+	assert.Equal(t, float64(2), got["tools_count"])
+	assert.Equal(t, float64(1), got["tools_failed"])
+}
+
 func TestRun_DebugMode(t *testing.T) {
 	dir := t.TempDir()
 	// The debug file is written relative to os.Executable(), which during tests
@@ -564,6 +812,42 @@ func TestRun_SingleLineEnv(t *testing.T) {
 	assert.NotEmpty(t, stdout.String())
 }
 
+// TestRun_CostSegment verifies the stdin cost block reaches the rendered
+// output, lines delta included, and disappears at zero cost.
+func TestRun_CostSegment(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	var stdout, stderr strings.Builder
+	run(strings.NewReader(minimalInput), &stdout, &stderr, []string{"statusline"})
+	assert.Empty(t, stderr.String())
+	assert.Contains(t, stdout.String(), "💰 $1.00 +10/-2 lines · I:50.0K O:10.0K")
+
+	stdout.Reset()
+	zeroCost := strings.Replace(minimalInput, `"total_cost_usd": 1.0`, `"total_cost_usd": 0`, 1)
+	run(strings.NewReader(zeroCost), &stdout, &stderr, []string{"statusline"})
+	assert.NotContains(t, stdout.String(), "💰")
+}
+
+func TestRun_NoColor(t *testing.T) {
+	t.Setenv("STATUSLINE_SINGLELINE", "")
+
+	t.Run("colour by default", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "")
+		var stdout, stderr strings.Builder
+		run(strings.NewReader(minimalInput), &stdout, &stderr, []string{"statusline"})
+		assert.Contains(t, stdout.String(), "\x1b[")
+	})
+
+	t.Run("NO_COLOR strips all escape sequences", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "1")
+		var stdout, stderr strings.Builder
+		run(strings.NewReader(minimalInput), &stdout, &stderr, []string{"statusline"})
+		assert.Empty(t, stderr.String())
+		assert.Contains(t, stdout.String(), "Sonnet")
+		assert.NotContains(t, stdout.String(), "\x1b")
+	})
+}
+
 // TestRun_WindowsNarrowBlockWidth verifies that on Windows without WT_SESSION,
 // layout.UseNarrowBlockWidth is set to true.
 func TestRun_WindowsNarrowBlockWidth(t *testing.T) {
@@ -586,3 +870,43 @@ func TestRun_WindowsNarrowBlockWidth(t *testing.T) {
 	assert.True(t, layout.UseNarrowBlockWidth, "should be narrow on windows without WT_SESSION")
 	layout.UseNarrowBlockWidth = false // restore
 }
+
+// TestRun_ClaudeVersionHiddenByDefault verifies the version cell is off
+// unless display.show opts in to it.
+func TestRun_ClaudeVersionHiddenByDefault(t *testing.T) {
+	t.Setenv("STATUSLINE_SINGLELINE", "")
+	t.Setenv("NO_COLOR", "1")
+	t.Setenv(claudedir.EnvVar, t.TempDir())
+
+	tests := []struct {
+		name   string
+		config string
+		want   bool
+	}{
+		{"no config", "", false},
+		{"opted in via show", "display:\n  show: [claude-version, folder]\n", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			dir := t.TempDir()
+			if tt.config != "" {
+				require.NoError(t, os.MkdirAll(filepath.Join(dir, ".claude"), 0755))
+				require.NoError(t, os.WriteFile(filepath.Join(dir, ".claude", "statusline.yml"), []byte(tt.config), 0644))
+			}
+			cwd, err := json.Marshal(dir)
+			require.NoError(t, err)
+			input := `{"cwd": ` + string(cwd) + `, "version": "2.1.4",
+				"model": {"id": "claude-sonnet-4-5", "display_name": "Sonnet 4.5"}}`
+
+			// Act
+			var stdout, stderr strings.Builder
+			run(strings.NewReader(input), &stdout, &stderr, []string{"statusline"})
+
+			// Assert
+			assert.Contains(t, stdout.String(), filepath.Base(dir))
+			assert.Equal(t, tt.want, strings.Contains(stdout.String(), "v2.1.4"))
+		})
+	}
+}
@@ -1,13 +1,20 @@
 package parser
 
 import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"path"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/young1lin/claude-token-monitor/internal/textutil"
+	"github.com/young1lin/claude-token-monitor/internal/timeutil"
 )
 
 // TranscriptEntry represents a single entry in the transcript JSONL file
@@ -16,6 +23,17 @@ type TranscriptEntry struct {
 	Message   *MessageContent `json:"message,omitempty"`
 	Timestamp string          `json:"timestamp,omitempty"`
 	GitBranch string          `json:"git_branch,omitempty"`
+	Cost      *EntryCost      `json:"cost,omitempty"`
+}
+
+// EntryCost is the cumulative session cost block, in the same shape Claude
+// Code sends on the statusline's stdin. Values are running totals, so the
+// newest entry carrying one wins.
+type EntryCost struct {
+	TotalCostUSD      float64 `json:"total_cost_usd"`
+	TotalDurationMs   int     `json:"total_duration_ms"`
+	TotalLinesAdded   int     `json:"total_lines_added"`
+	TotalLinesRemoved int     `json:"total_lines_removed"`
 }
 
 // MessageContent represents the message content in a transcript entry.
@@ -75,6 +93,26 @@ type TranscriptSummary struct {
 	InputTokens    int
 	OutputTokens   int
 	CacheTokens    int
+	// CacheCreationTokens counts cache writes separately from CacheTokens
+	// (cache reads): writes are billed at a premium over normal input.
+	CacheCreationTokens int
+	TotalCostUSD        float64
+	TotalDurationMs     int
+	LinesAdded          int
+	LinesRemoved        int
+	// AwaitingApproval names the tool Claude Code appears to be blocked on
+	// waiting for the user's permission; empty otherwise. See
+	// approvalWaitThreshold for the heuristic.
+	AwaitingApproval string
+	// ContextTokens is the context occupied as of the newest assistant
+	// message (input + cache reads + cache writes + output). A "summary"
+	// entry resets it, so after a compaction it reflects the compacted
+	// window rather than the pre-compaction peak. The cumulative token
+	// fields above keep counting across compactions.
+	ContextTokens int
+	// Compactions counts the "summary" entries Claude Code wrote when it
+	// compacted the context.
+	Compactions int
 }
 
 // AgentInfo represents information about a running agent
@@ -88,9 +126,10 @@ type AgentInfo struct {
 
 // TokenUsage represents token usage information
 type TokenUsage struct {
-	InputTokens          int `json:"input_tokens"`
-	OutputTokens         int `json:"output_tokens"`
-	CacheReadInputTokens int `json:"cache_read_input_tokens"`
+	InputTokens              int `json:"input_tokens"`
+	OutputTokens             int `json:"output_tokens"`
+	CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+	CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
 }
 
 // In-memory cache — only useful when the same process calls this function
@@ -98,17 +137,36 @@ type TokenUsage struct {
 var (
 	transcriptCache          *TranscriptSummary
 	transcriptCachePath      string
+	transcriptCacheLines     int // line cap the cached summary was parsed with
 	transcriptCacheMu        sync.RWMutex
 	transcriptCacheMtime     time.Time // file mtime recorded at last parse
 	transcriptCacheParseTime time.Time // wall time of last parse (for TTL)
 	transcriptCacheTTL       = 5 * time.Second
 )
 
+// fullTranscriptCache holds the last ParseTranscriptFull result, keyed and
+// expired the same way as transcriptCache.
+var (
+	fullTranscriptCache          *TranscriptSummary
+	fullTranscriptCachePath      string
+	fullTranscriptCacheMtime     time.Time
+	fullTranscriptCacheParseTime time.Time
+	fullTranscriptCacheMu        sync.RWMutex
+)
+
 // nowFn is the injection point used to expire the cache without an actual
 // time.Sleep — tests override this to advance virtual wall time. Production
 // callers see time.Now. Mirrors the pattern in content/time.go.
 var nowFn = time.Now
 
+// approvalWaitThreshold is how long a trailing tool_use must sit without a
+// result before it is reported as awaiting approval. The transcript carries
+// no explicit permission-prompt marker, so "the last entry is an unanswered
+// tool call and nothing has been written since" is the best signal we have.
+// Auto-approved tools normally produce their result well within this window;
+// a genuinely long-running command (e.g. a slow build) will also trip it.
+var approvalWaitThreshold = 5 * time.Second
+
 // ParseTranscriptLastNLines reads and parses the transcript file
 func ParseTranscriptLastNLines(transcriptPath string, n int) (*TranscriptSummary, error) {
 	return ParseTranscriptLastNLinesWithProjectPath(transcriptPath, n, "")
@@ -116,8 +174,10 @@ func ParseTranscriptLastNLines(transcriptPath string, n int) (*TranscriptSummary
 
 // ParseTranscriptLastNLinesWithProjectPath parses the transcript.
 // Reads up to 512 KB from the end of the file to cover a full turn's entries
-// even when tool results contain large file contents (e.g. the Read tool).
-func ParseTranscriptLastNLinesWithProjectPath(transcriptPath string, _ int, projectPath string) (*TranscriptSummary, error) {
+// even when tool results contain large file contents (e.g. the Read tool),
+// and hands that window to ParseTranscriptStream, which keeps its last n
+// lines (n <= 0 keeps the whole window).
+func ParseTranscriptLastNLinesWithProjectPath(transcriptPath string, n int, projectPath string) (*TranscriptSummary, error) {
 	if transcriptPath == "" {
 		return &TranscriptSummary{}, nil
 	}
@@ -132,7 +192,7 @@ func ParseTranscriptLastNLinesWithProjectPath(transcriptPath string, _ int, proj
 
 	// In-memory cache: helps when multiple collectors call this within one invocation.
 	transcriptCacheMu.RLock()
-	if transcriptCache != nil && transcriptCachePath == transcriptPath &&
+	if transcriptCache != nil && transcriptCachePath == transcriptPath && transcriptCacheLines == n &&
 		transcriptCacheMtime.Equal(fileMtime) && now.Sub(transcriptCacheParseTime) < transcriptCacheTTL {
 		cached := *transcriptCache
 		transcriptCacheMu.RUnlock()
@@ -140,19 +200,20 @@ func ParseTranscriptLastNLinesWithProjectPath(transcriptPath string, _ int, proj
 	}
 	transcriptCacheMu.RUnlock()
 
-	file, err := os.Open(transcriptPath)
+	file, r, err := openTranscript(transcriptPath)
 	if err != nil {
 		return &TranscriptSummary{}, nil
 	}
 	defer file.Close()
 
-	stat, err := file.Stat()
+	tail, err := transcriptTailReader(r)
+	if err != nil {
+		return &TranscriptSummary{}, nil
+	}
+	summary, err := ParseTranscriptStream(tail, n)
 	if err != nil {
 		return &TranscriptSummary{}, nil
 	}
-
-	entries := readCurrentTurnEntries(file, stat.Size())
-	summary := analyzeTranscriptEntries(entries)
 
 	if summary.GitBranch == "" && projectPath != "" {
 		summary.GitBranch = getGitBranchForPath(projectPath)
@@ -161,6 +222,7 @@ func ParseTranscriptLastNLinesWithProjectPath(transcriptPath string, _ int, proj
 	transcriptCacheMu.Lock()
 	transcriptCache = summary
 	transcriptCachePath = transcriptPath
+	transcriptCacheLines = n
 	transcriptCacheMtime = fileMtime
 	transcriptCacheParseTime = now
 	transcriptCacheMu.Unlock()
@@ -168,39 +230,245 @@ func ParseTranscriptLastNLinesWithProjectPath(transcriptPath string, _ int, proj
 	return summary, nil
 }
 
-// readCurrentTurnEntries reads up to 512 KB from the end of the file,
-// scans backwards to locate the last real user message, then fully parses
-// only the entries from that message to EOF.
-//
-// Large tool-result entries (e.g. the Read tool embeds the entire file content)
-// that predate the current turn are skipped with a cheap string check, avoiding
-// unnecessary JSON deserialization of hundreds of KB.
-func readCurrentTurnEntries(f *os.File, fileSize int64) []TranscriptEntry {
-	if fileSize == 0 {
-		return nil
+// ParseTranscriptFull parses the entire transcript file, streaming it line by
+// line so memory stays flat regardless of session length. Unlike the tail
+// parser, CompletedTools and FailedTools aggregate across the whole session;
+// ActiveTools is still scoped to the current turn. Results are cached by path
+// and mtime like the tail parser's.
+func ParseTranscriptFull(transcriptPath string) (*TranscriptSummary, error) {
+	if transcriptPath == "" {
+		return &TranscriptSummary{}, nil
+	}
+
+	info, err := os.Stat(transcriptPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open transcript: %w", err)
+	}
+	now := nowFn()
+	fullTranscriptCacheMu.RLock()
+	if fullTranscriptCache != nil && fullTranscriptCachePath == transcriptPath &&
+		fullTranscriptCacheMtime.Equal(info.ModTime()) && now.Sub(fullTranscriptCacheParseTime) < transcriptCacheTTL {
+		cached := *fullTranscriptCache
+		fullTranscriptCacheMu.RUnlock()
+		return &cached, nil
+	}
+	fullTranscriptCacheMu.RUnlock()
+
+	summary, err := parseTranscriptFull(transcriptPath)
+	if err != nil {
+		return nil, err
+	}
+
+	fullTranscriptCacheMu.Lock()
+	fullTranscriptCache = summary
+	fullTranscriptCachePath = transcriptPath
+	fullTranscriptCacheMtime = info.ModTime()
+	fullTranscriptCacheParseTime = now
+	fullTranscriptCacheMu.Unlock()
+
+	cached := *summary
+	return &cached, nil
+}
+
+// parseTranscriptFull does the uncached work of ParseTranscriptFull.
+func parseTranscriptFull(transcriptPath string) (*TranscriptSummary, error) {
+	file, r, err := openTranscript(transcriptPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open transcript: %w", err)
+	}
+	defer file.Close()
+
+	a := newTranscriptAnalyzer()
+	reader := bufio.NewReader(r)
+	for {
+		// ReadBytes rather than bufio.Scanner: tool results can embed whole
+		// files, so a single JSONL line easily exceeds Scanner's token limit.
+		line, readErr := reader.ReadBytes('\n')
+		if trimmed := bytes.TrimSpace(line); len(trimmed) > 0 {
+			var entry TranscriptEntry
+			if json.Unmarshal(trimmed, &entry) == nil {
+				if isRealUserMessage(entry) {
+					a.resetPending()
+				}
+				a.addSessionInfo(entry)
+				a.addToolStats(entry)
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return nil, fmt.Errorf("failed to read transcript: %w", readErr)
+		}
+	}
+
+	return a.finish(), nil
+}
+
+// CountUserTurns counts the real user messages (not tool_result submissions)
+// in the whole transcript. It has to read the entire file, so callers should
+// only use it when the count is actually displayed. Lines that cannot be a
+// user entry are skipped with a cheap byte check before any JSON decoding.
+func CountUserTurns(transcriptPath string) (int, error) {
+	if transcriptPath == "" {
+		return 0, nil
 	}
 
-	const readWindow = 512 * 1024
-	offset := fileSize - readWindow
+	file, r, err := openTranscript(transcriptPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open transcript: %w", err)
+	}
+	defer file.Close()
+
+	userMarker := []byte(`"type":"user"`)
+	turns := 0
+	reader := bufio.NewReader(r)
+	for {
+		line, readErr := reader.ReadBytes('\n')
+		if bytes.Contains(line, userMarker) {
+			var entry TranscriptEntry
+			if json.Unmarshal(bytes.TrimSpace(line), &entry) == nil && isRealUserMessage(entry) {
+				turns++
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return 0, fmt.Errorf("failed to read transcript: %w", readErr)
+		}
+	}
+	return turns, nil
+}
+
+// transcriptTailWindow is how much of the end of a transcript the tail
+// parser reads: enough to cover a full turn's entries even when tool results
+// embed large file contents.
+const transcriptTailWindow = 512 * 1024
+
+// gzipMagic is the two-byte header every gzip stream starts with.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// isGzipTranscript reports whether the transcript has been rotated to gzip:
+// a .gz suffix, or gzip magic bytes for a file compressed in place.
+func isGzipTranscript(f *os.File, path string) bool {
+	if strings.HasSuffix(strings.ToLower(path), ".gz") {
+		return true
+	}
+	header := make([]byte, len(gzipMagic))
+	n, _ := f.ReadAt(header, 0)
+	return n == len(gzipMagic) && bytes.Equal(header, gzipMagic)
+}
+
+// openTranscript opens the transcript at path. A gzip-rotated transcript is
+// returned as a decompressing reader; a plain one as the file itself, so the
+// tail parser can still seek. Callers close the returned file.
+func openTranscript(path string) (*os.File, io.Reader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !isGzipTranscript(f, path) {
+		return f, f, nil
+	}
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	return f, gz, nil
+}
+
+// transcriptTailReader returns a reader over the last 512 KB of a
+// transcript opened by openTranscript. A gzip stream cannot be seeked, so it
+// is decompressed through a bounded tail buffer instead.
+func transcriptTailReader(r io.Reader) (io.Reader, error) {
+	f, ok := r.(*os.File)
+	if !ok {
+		tail, err := readTail(r, transcriptTailWindow)
+		if err != nil {
+			return nil, err
+		}
+		return bytes.NewReader(tail), nil
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	offset := stat.Size() - transcriptTailWindow
 	if offset < 0 {
 		offset = 0
 	}
+	return io.NewSectionReader(f, offset, stat.Size()-offset), nil
+}
 
-	buf := make([]byte, fileSize-offset)
-	n, err := f.ReadAt(buf, offset)
-	if err != nil && err != io.EOF {
-		return nil
+// readTail reads r to EOF and returns its last window bytes. Memory stays
+// within twice the window however long the stream is. Like the plain-file
+// tail, the first line may be cut short; the parser skips it as invalid JSON.
+func readTail(r io.Reader, window int) ([]byte, error) {
+	buf := make([]byte, 0, 2*window)
+	chunk := make([]byte, min(32*1024, window))
+	for {
+		n, err := r.Read(chunk)
+		buf = append(buf, chunk[:n]...)
+		if len(buf) > window {
+			buf = append(buf[:0], buf[len(buf)-window:]...)
+		}
+		if err == io.EOF {
+			return buf, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// ParseTranscriptStream parses a JSONL transcript from r, keeping only the
+// last n non-empty lines in a circular buffer (n <= 0 keeps every line). As
+// with the file-based parser, tool statistics cover the current turn: the
+// entries from the last real user message within those lines onward.
+func ParseTranscriptStream(r io.Reader, n int) (*TranscriptSummary, error) {
+	lines, err := readLastLines(r, n)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transcript: %w", err)
 	}
+	return analyzeTranscriptEntries(currentTurnEntries(lines)), nil
+}
 
-	// Collect non-empty lines (JSONL).
-	rawLines := strings.Split(string(buf[:n]), "\n")
-	var lines []string
-	for _, l := range rawLines {
-		l = strings.TrimSpace(l)
-		if l != "" {
-			lines = append(lines, l)
+// readLastLines returns the last n non-empty, trimmed lines of r in order,
+// or all of them when n <= 0.
+func readLastLines(r io.Reader, n int) ([]string, error) {
+	var ring []string
+	next := 0 // once the ring is full, the slot holding the oldest line
+	reader := bufio.NewReader(r)
+	for {
+		// ReadString rather than bufio.Scanner: tool results can embed whole
+		// files, so a single JSONL line easily exceeds Scanner's token limit.
+		line, readErr := reader.ReadString('\n')
+		if line = strings.TrimSpace(line); line != "" {
+			if n <= 0 || len(ring) < n {
+				ring = append(ring, line)
+			} else {
+				ring[next] = line
+				next = (next + 1) % n
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return nil, readErr
 		}
 	}
+	return append(ring[next:], ring[:next]...), nil
+}
+
+// currentTurnEntries parses the entries from the last real user message in
+// lines onward.
+func currentTurnEntries(lines []string) []TranscriptEntry {
+	if len(lines) == 0 {
+		return nil
+	}
 
 	// Scan backwards to find the last real user message.
 	// Use a cheap contains check before full JSON parsing so that large
@@ -247,15 +515,7 @@ func isRealUserMessage(entry TranscriptEntry) bool {
 // Session-level information (git branch, timestamps, agents, todos) is gathered
 // from all entries.
 func analyzeTranscriptEntries(entries []TranscriptEntry) *TranscriptSummary {
-	summary := &TranscriptSummary{
-		CompletedTools: make(map[string]int),
-		FailedTools:    make(map[string]int),
-		Agents:         []AgentInfo{},
-	}
-
-	if len(entries) == 0 {
-		return summary
-	}
+	a := newTranscriptAnalyzer()
 
 	// Find the index of the last real user message.
 	// Tool tracking starts from that point so we only show tools used in the
@@ -268,106 +528,189 @@ func analyzeTranscriptEntries(entries []TranscriptEntry) *TranscriptSummary {
 		}
 	}
 
-	// toolIDToName maps tool_use ID -> tool name (scoped to current turn)
-	toolIDToName := make(map[string]string)
-	// pendingIDs tracks tool_use IDs that have not yet received a result
-	pendingIDs := make(map[string]bool)
-
 	// Process all entries in forward order
 	for i, entry := range entries {
-		// ── Session-level info (whole history) ──────────────────────────────
+		a.addSessionInfo(entry)
 
-		if summary.GitBranch == "" && entry.GitBranch != "" {
-			summary.GitBranch = entry.GitBranch
+		// Skip entries before (and including) the last real user message.
+		if i <= lastUserMsgIdx {
+			continue
 		}
+		a.addToolStats(entry)
+	}
 
-		if entry.Timestamp != "" {
-			if t, err := time.Parse(time.RFC3339, entry.Timestamp); err == nil {
-				if summary.SessionStart.IsZero() || t.Before(summary.SessionStart) {
-					summary.SessionStart = t
-				}
-				if summary.SessionEnd.IsZero() || t.After(summary.SessionEnd) {
-					summary.SessionEnd = t
-				}
-			}
-		}
+	return a.finish()
+}
 
-		if entry.Type == "assistant" && entry.Message != nil {
-			summary.InputTokens += entry.Message.Usage.InputTokens
-			summary.OutputTokens += entry.Message.Usage.OutputTokens
-			summary.CacheTokens += entry.Message.Usage.CacheReadInputTokens
-			summary.TotalTokens = summary.InputTokens + summary.OutputTokens
-
-			for _, content := range entry.Message.contentItems() {
-				if content.Type == "tool_use" {
-					// Agents and todos are tracked across the full session
-					if content.Name == "Task" {
-						agentType := "general-purpose"
-						if subagentType, ok := content.Input["subagent_type"].(string); ok {
-							agentType = subagentType
-						}
-						agentDesc := ""
-						if desc, ok := content.Input["description"].(string); ok {
-							agentDesc = desc
-						}
-						summary.Agents = append(summary.Agents, AgentInfo{
-							Type: agentType,
-							Desc: agentDesc,
-						})
-					} else if content.Name == "TodoWrite" {
-						extractTodoInfo(content.Input, summary)
-					}
-				}
+// transcriptAnalyzer accumulates a TranscriptSummary one entry at a time, so
+// the tail parser (a slice of current-turn entries) and the streaming full
+// parser share the same extraction rules.
+type transcriptAnalyzer struct {
+	summary *TranscriptSummary
+	// toolIDToName maps tool_use ID -> tool name
+	toolIDToName map[string]string
+	// pendingIDs tracks tool_use IDs that have not yet received a result
+	pendingIDs map[string]bool
+	// trailingToolID / trailingToolAt identify the tool_use in the most
+	// recent message entry, if that entry was an assistant tool call. Any
+	// later message clears them.
+	trailingToolID string
+	trailingToolAt time.Time
+}
+
+func newTranscriptAnalyzer() *transcriptAnalyzer {
+	return &transcriptAnalyzer{
+		summary: &TranscriptSummary{
+			CompletedTools: make(map[string]int),
+			FailedTools:    make(map[string]int),
+			Agents:         []AgentInfo{},
+		},
+		toolIDToName: make(map[string]string),
+		pendingIDs:   make(map[string]bool),
+	}
+}
+
+// addSessionInfo records session-level info: git branch, timestamps, token
+// usage, compactions, agents and todos.
+func (a *transcriptAnalyzer) addSessionInfo(entry TranscriptEntry) {
+	summary := a.summary
+
+	if summary.GitBranch == "" && entry.GitBranch != "" {
+		summary.GitBranch = entry.GitBranch
+	}
+
+	if entry.Timestamp != "" {
+		if t, err := time.Parse(time.RFC3339, entry.Timestamp); err == nil {
+			if summary.SessionStart.IsZero() || t.Before(summary.SessionStart) {
+				summary.SessionStart = t
+			}
+			if summary.SessionEnd.IsZero() || t.After(summary.SessionEnd) {
+				summary.SessionEnd = t
 			}
 		}
+	}
 
-		// ── Tool call stats (current turn only) ─────────────────────────────
-		// Skip entries before (and including) the last real user message.
-		if i <= lastUserMsgIdx {
+	if entry.Cost != nil {
+		summary.TotalCostUSD = entry.Cost.TotalCostUSD
+		summary.TotalDurationMs = entry.Cost.TotalDurationMs
+		summary.LinesAdded = entry.Cost.TotalLinesAdded
+		summary.LinesRemoved = entry.Cost.TotalLinesRemoved
+	}
+
+	if entry.Type == "summary" {
+		summary.Compactions++
+		summary.ContextTokens = 0
+		return
+	}
+
+	if entry.Type != "assistant" || entry.Message == nil {
+		return
+	}
+
+	usage := entry.Message.Usage
+	if ctx := usage.InputTokens + usage.CacheReadInputTokens + usage.CacheCreationInputTokens + usage.OutputTokens; ctx > 0 {
+		summary.ContextTokens = ctx
+	}
+
+	summary.InputTokens += entry.Message.Usage.InputTokens
+	summary.OutputTokens += entry.Message.Usage.OutputTokens
+	summary.CacheTokens += entry.Message.Usage.CacheReadInputTokens
+	summary.CacheCreationTokens += entry.Message.Usage.CacheCreationInputTokens
+	summary.TotalTokens = summary.InputTokens + summary.OutputTokens
+
+	for _, content := range entry.Message.contentItems() {
+		if content.Type != "tool_use" {
 			continue
 		}
+		// Agents and todos are tracked across the full session
+		if content.Name == "Task" {
+			agentType := "general-purpose"
+			if subagentType, ok := content.Input["subagent_type"].(string); ok {
+				agentType = subagentType
+			}
+			agentDesc := ""
+			if desc, ok := content.Input["description"].(string); ok {
+				agentDesc = desc
+			}
+			summary.Agents = append(summary.Agents, AgentInfo{
+				Type: agentType,
+				Desc: agentDesc,
+			})
+		} else if content.Name == "TodoWrite" {
+			extractTodoInfo(content.Input, summary)
+		}
+	}
+}
 
-		if entry.Type == "assistant" && entry.Message != nil {
-			for _, content := range entry.Message.contentItems() {
-				if content.Type == "tool_use" && content.ID != "" && content.Name != "" &&
-					content.Name != "Task" && content.Name != "TodoWrite" {
-					toolIDToName[content.ID] = content.Name
-					pendingIDs[content.ID] = true
-				}
+// addToolStats records tool_use calls and matches tool_result entries to
+// them, counting completions and failures.
+func (a *transcriptAnalyzer) addToolStats(entry TranscriptEntry) {
+	if entry.Message == nil {
+		return
+	}
+
+	a.trailingToolID = ""
+	if entry.Type == "assistant" {
+		for _, content := range entry.Message.contentItems() {
+			if content.Type == "tool_use" && content.ID != "" && content.Name != "" &&
+				content.Name != "Task" && content.Name != "TodoWrite" {
+				a.toolIDToName[content.ID] = content.Name
+				a.pendingIDs[content.ID] = true
+				a.trailingToolID = content.ID
 			}
 		}
+		if a.trailingToolID != "" {
+			a.trailingToolAt, _ = time.Parse(time.RFC3339, entry.Timestamp)
+		}
+	}
 
-		// tool_result entries come in as type "user" with content items of type "tool_result".
-		// Do NOT break early: parallel tool calls produce multiple tool_result items
-		// inside the same user entry.
-		if entry.Type == "user" && entry.Message != nil {
-			for _, content := range entry.Message.contentItems() {
-				if content.Type != "tool_result" {
-					continue
-				}
-				toolName := toolIDToName[content.ToolUseID]
-				if toolName != "" {
-					delete(pendingIDs, content.ToolUseID)
-					if content.IsError {
-						summary.FailedTools[toolName]++
-					} else {
-						summary.CompletedTools[toolName]++
-					}
+	// tool_result entries come in as type "user" with content items of type "tool_result".
+	// Do NOT break early: parallel tool calls produce multiple tool_result items
+	// inside the same user entry.
+	if entry.Type == "user" {
+		for _, content := range entry.Message.contentItems() {
+			if content.Type != "tool_result" {
+				continue
+			}
+			toolName := a.toolIDToName[content.ToolUseID]
+			if toolName != "" {
+				delete(a.pendingIDs, content.ToolUseID)
+				if content.IsError {
+					a.summary.FailedTools[toolName]++
+				} else {
+					a.summary.CompletedTools[toolName]++
 				}
 			}
 		}
 	}
+}
+
+// resetPending forgets tool_use calls still awaiting a result. Called at each
+// real user message by the full parser so ActiveTools stays scoped to the
+// current turn even though completion counts span the whole session.
+func (a *transcriptAnalyzer) resetPending() {
+	a.pendingIDs = make(map[string]bool)
+	a.trailingToolID = ""
+}
 
-	// ActiveTools = tool_use calls in the current turn with no result yet
+// finish derives ActiveTools and returns the accumulated summary.
+func (a *transcriptAnalyzer) finish() *TranscriptSummary {
+	// ActiveTools = tool_use calls with no result yet
 	seenActive := make(map[string]bool)
-	for id := range pendingIDs {
-		if name, ok := toolIDToName[id]; ok && !seenActive[name] {
+	for id := range a.pendingIDs {
+		if name, ok := a.toolIDToName[id]; ok && !seenActive[name] {
 			seenActive[name] = true
-			summary.ActiveTools = append(summary.ActiveTools, name)
+			a.summary.ActiveTools = append(a.summary.ActiveTools, name)
 		}
 	}
 
-	return summary
+	// AwaitingApproval = the transcript ends on an unanswered tool call that
+	// has been waiting longer than approvalWaitThreshold
+	if id := a.trailingToolID; id != "" && a.pendingIDs[id] && !a.trailingToolAt.IsZero() &&
+		nowFn().Sub(a.trailingToolAt) >= approvalWaitThreshold {
+		a.summary.AwaitingApproval = a.toolIDToName[id]
+	}
+	return a.summary
 }
 
 // extractTodoInfo extracts TODO information from a TodoWrite tool call
@@ -405,23 +748,13 @@ func extractTodoInfo(input map[string]interface{}, summary *TranscriptSummary) {
 	summary.TodoTotal = total
 }
 
-// GetSessionDuration formats the session duration
+// GetSessionDuration formats the session duration with timeutil.FormatDuration
 func GetSessionDuration(summary *TranscriptSummary) string {
 	if summary.SessionStart.IsZero() || summary.SessionEnd.IsZero() {
 		return ""
 	}
 
-	duration := summary.SessionEnd.Sub(summary.SessionStart)
-
-	if duration < time.Minute {
-		return fmt.Sprintf("%ds", int(duration.Seconds()))
-	} else if duration < time.Hour {
-		return fmt.Sprintf("%dm", int(duration.Minutes()))
-	} else {
-		hours := int(duration.Hours())
-		mins := int(duration.Minutes()) % 60
-		return fmt.Sprintf("%dh%dm", hours, mins)
-	}
+	return timeutil.FormatDuration(summary.SessionEnd.Sub(summary.SessionStart), 0)
 }
 
 // FormatActiveTools creates a compact string of active tools
@@ -439,10 +772,7 @@ func FormatActiveTools(summary *TranscriptSummary) string {
 	for _, tool := range summary.ActiveTools {
 		shortName := tool
 		if strings.HasPrefix(tool, "mcp__") {
-			shortName = "mcp:" + tool[5:]
-			if len(shortName) > 15 {
-				shortName = shortName[:12] + ".."
-			}
+			shortName = textutil.Truncate("mcp:"+tool[5:], 15)
 		}
 		toolNames = append(toolNames, shortName)
 	}
@@ -473,13 +803,8 @@ func FormatAgentInfo(summary *TranscriptSummary) string {
 	info := agent.Type
 
 	if agent.Desc != "" {
-		desc := agent.Desc
-		// Use rune count for proper UTF-8 (Chinese character) handling
-		runes := []rune(desc)
-		if len(runes) > 20 {
-			desc = string(runes[:17]) + ".."
-		}
-		info = fmt.Sprintf("%s: %s", info, desc)
+		// Rune-aware so Chinese descriptions aren't cut mid-character
+		info = fmt.Sprintf("%s: %s", info, textutil.Truncate(agent.Desc, 20))
 	}
 
 	// Add elapsed time if available
@@ -516,6 +841,26 @@ func FormatCompletedTools(summary *TranscriptSummary) string {
 	return fmt.Sprintf("%d tools", total)
 }
 
+// FormatCostFromTranscript formats the session cost recorded in the
+// transcript, e.g. "$1.23 +120/-8 lines". Amounts below $1 keep four
+// decimals. Returns "" when no cost was recorded.
+func FormatCostFromTranscript(summary *TranscriptSummary) string {
+	if summary == nil || summary.TotalCostUSD <= 0 {
+		return ""
+	}
+
+	var result string
+	if summary.TotalCostUSD < 1 {
+		result = fmt.Sprintf("$%.4f", summary.TotalCostUSD)
+	} else {
+		result = fmt.Sprintf("$%.2f", summary.TotalCostUSD)
+	}
+	if summary.LinesAdded > 0 || summary.LinesRemoved > 0 {
+		result += fmt.Sprintf(" +%d/-%d lines", summary.LinesAdded, summary.LinesRemoved)
+	}
+	return result
+}
+
 // GetProjectName extracts the project directory name for display
 func GetProjectName(cwd string, projectDir string) string {
 	dir := cwd
@@ -524,13 +869,15 @@ func GetProjectName(cwd string, projectDir string) string {
 	}
 
 	// Normalize backslashes manually — filepath.ToSlash only replaces
-	// os.PathSeparator, which is '/' on Linux (no-op for '\').
-	parts := strings.Split(strings.ReplaceAll(dir, "\\", "/"), "/")
-	name := parts[len(parts)-1]
-	if len(name) > 20 {
-		return name[:17] + ".."
+	// os.PathSeparator, which is '/' on Linux (no-op for '\'). That also
+	// covers mixed separators (WSL worktrees) and UNC paths. path.Base, not
+	// filepath.Base, so the result doesn't depend on the host OS; trailing
+	// separators are trimmed first so `C:\proj\` still yields "proj".
+	dir = strings.TrimRight(strings.ReplaceAll(dir, "\\", "/"), "/")
+	if dir == "" {
+		return ""
 	}
-	return name
+	return textutil.Truncate(path.Base(dir), 20)
 }
 
 // getGitBranchForPath reads the current git branch using git command for a given path
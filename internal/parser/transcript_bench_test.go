@@ -0,0 +1,100 @@
+package parser
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// generatedTranscriptStart is the timestamp of the first entry written by
+// writeLargeTranscript; each later entry is one second newer.
+var generatedTranscriptStart = time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+// writeLargeTranscript writes a transcript of at least targetBytes made of
+// repeated turns: a user message, an assistant tool_use carrying 100 input /
+// 50 output tokens, and a tool_result padded to ~4 KB like a Read result.
+// Returns the path and the number of assistant entries written.
+func writeLargeTranscript(tb testing.TB, targetBytes int) (string, int) {
+	tb.Helper()
+	path := filepath.Join(tb.TempDir(), "large.jsonl")
+	f, err := os.Create(path)
+	require.NoError(tb, err)
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	padding := strings.Repeat("x", 4096)
+	written, assistants := 0, 0
+	ts := generatedTranscriptStart
+	emit := func(e TranscriptEntry) {
+		e.Timestamp = ts.Format(time.RFC3339)
+		ts = ts.Add(time.Second)
+		raw, err := json.Marshal(e)
+		require.NoError(tb, err)
+		n, _ := w.Write(append(raw, '\n'))
+		written += n
+	}
+
+	for i := 0; written < targetBytes; i++ {
+		id := fmt.Sprintf("t%d", i)
+		emit(makeUserTextEntry("next step"))
+		emit(makeAssistantEntry(100, 50, 0, []ContentItem{{Type: "tool_use", ID: id, Name: "Read"}}))
+		assistants++
+		result, _ := json.Marshal([]map[string]interface{}{{"type": "tool_result", "tool_use_id": id, "content": padding}})
+		emit(TranscriptEntry{Type: "user", Message: &MessageContent{Content: result}})
+	}
+	require.NoError(tb, w.Flush())
+	return path, assistants
+}
+
+// TestParseTranscriptFull_SessionStartOutsideTail verifies the full parser
+// sees the first entry's timestamp and whole-session token totals on a file
+// larger than the tail window, where the tail parser cannot.
+func TestParseTranscriptFull_SessionStartOutsideTail(t *testing.T) {
+	// Arrange
+	path, assistants := writeLargeTranscript(t, 2*transcriptTailWindow)
+
+	// Act
+	full, err := ParseTranscriptFull(path)
+	require.NoError(t, err)
+	tail, err := ParseTranscriptLastNLines(path, 100)
+	require.NoError(t, err)
+
+	// Assert
+	assert.True(t, full.SessionStart.Equal(generatedTranscriptStart))
+	assert.True(t, tail.SessionStart.After(generatedTranscriptStart), "tail parser only sees the end of the file")
+	assert.Equal(t, assistants*100, full.InputTokens)
+	assert.Equal(t, assistants*50, full.OutputTokens)
+	assert.Less(t, tail.InputTokens, full.InputTokens)
+	assert.Equal(t, assistants, full.CompletedTools["Read"])
+}
+
+// BenchmarkParseTranscript compares the latency-sensitive tail parser with
+// the full streaming parser on a 50 MB transcript.
+func BenchmarkParseTranscript(b *testing.B) {
+	path, _ := writeLargeTranscript(b, 50<<20)
+
+	b.Run("tail", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			clearTranscriptCache()
+			if _, err := ParseTranscriptLastNLines(path, 100); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("full", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := ParseTranscriptFull(path); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
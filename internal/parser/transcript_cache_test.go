@@ -3,6 +3,7 @@ package parser
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -258,24 +259,13 @@ func TestParseTranscriptNonExistentFile(t *testing.T) {
 }
 
 func TestParseTranscriptInvalidJSON(t *testing.T) {
-	// Clear cache before test
-	clearTranscriptCache()
-
-	// Create transcript file with invalid JSON
-	tmpDir := t.TempDir()
-	transcriptPath := filepath.Join(tmpDir, "invalid.jsonl")
-
 	// First line is invalid JSON, second and third are valid
 	content := `{"type":"user_message","invalid json here without closing brace
 {"type":"user","timestamp":"2024-01-01T00:00:00Z"}
 {"type":"assistant","message":{"content":[{"type":"text"}],"usage":{"input_tokens":100,"output_tokens":50}}}
 `
-	if err := os.WriteFile(transcriptPath, []byte(content), 0644); err != nil {
-		t.Fatalf("Failed to create transcript file: %v", err)
-	}
-
 	// Should skip invalid lines and parse valid ones
-	summary, err := ParseTranscriptLastNLines(transcriptPath, 100)
+	summary, err := ParseTranscriptStream(strings.NewReader(content), 100)
 	if err != nil {
 		t.Fatalf("Parse failed: %v", err)
 	}
@@ -338,4 +328,59 @@ func clearTranscriptCache() {
 	transcriptCacheMtime = time.Time{}
 	transcriptCacheParseTime = time.Time{}
 	transcriptCacheMu.Unlock()
+
+	fullTranscriptCacheMu.Lock()
+	fullTranscriptCache = nil
+	fullTranscriptCachePath = ""
+	fullTranscriptCacheMtime = time.Time{}
+	fullTranscriptCacheParseTime = time.Time{}
+	fullTranscriptCacheMu.Unlock()
+}
+
+// TestParseTranscriptFullCache verifies the full parse is served from cache
+// while the mtime is unchanged and re-read once it moves.
+func TestParseTranscriptFullCache(t *testing.T) {
+	clearTranscriptCache()
+	t.Cleanup(clearTranscriptCache)
+
+	transcriptPath := filepath.Join(t.TempDir(), "test.jsonl")
+	line := `{"type":"assistant","message":{"content":[{"type":"tool_use","id":"t1","name":"Read","input":{}}]}}` + "\n"
+	if err := os.WriteFile(transcriptPath, []byte(line), 0644); err != nil {
+		t.Fatalf("Failed to create transcript file: %v", err)
+	}
+	info, err := os.Stat(transcriptPath)
+	if err != nil {
+		t.Fatalf("Failed to stat transcript: %v", err)
+	}
+	if _, err := ParseTranscriptFull(transcriptPath); err != nil {
+		t.Fatalf("First parse failed: %v", err)
+	}
+
+	// Append a second tool call but keep the original mtime
+	more := strings.Replace(line, `"t1","name":"Read"`, `"t2","name":"Grep"`, 1)
+	if err := os.WriteFile(transcriptPath, []byte(line+more), 0644); err != nil {
+		t.Fatalf("Failed to rewrite transcript: %v", err)
+	}
+	if err := os.Chtimes(transcriptPath, info.ModTime(), info.ModTime()); err != nil {
+		t.Fatalf("Failed to restore mtime: %v", err)
+	}
+	cached, err := ParseTranscriptFull(transcriptPath)
+	if err != nil {
+		t.Fatalf("Cached parse failed: %v", err)
+	}
+	if len(cached.ActiveTools) != 1 {
+		t.Errorf("ActiveTools = %v, want the cached single tool", cached.ActiveTools)
+	}
+
+	later := info.ModTime().Add(time.Minute)
+	if err := os.Chtimes(transcriptPath, later, later); err != nil {
+		t.Fatalf("Failed to bump mtime: %v", err)
+	}
+	fresh, err := ParseTranscriptFull(transcriptPath)
+	if err != nil {
+		t.Fatalf("Fresh parse failed: %v", err)
+	}
+	if len(fresh.ActiveTools) != 2 {
+		t.Errorf("ActiveTools = %v, want both tools after the mtime moved", fresh.ActiveTools)
+	}
 }
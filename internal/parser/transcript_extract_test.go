@@ -2,9 +2,13 @@ package parser
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"os"
 	"runtime"
+	"strings"
 	"testing"
+	"testing/iotest"
 	"time"
 
 	"github.com/stretchr/testify/assert"
@@ -610,6 +614,21 @@ func TestTokenAccumulation(t *testing.T) {
 		assert.Equal(t, 430, summary.TotalTokens) // InputTokens + OutputTokens
 	})
 
+	t.Run("cache creation tokens are tracked separately", func(t *testing.T) {
+		// Arrange: a message that only writes to the cache.
+		entry := makeAssistantEntry(0, 0, 0, nil)
+		entry.Message.Usage.CacheCreationInputTokens = 4000
+		entries := []TranscriptEntry{makeUserTextEntry("question"), entry}
+
+		// Act
+		summary := analyzeTranscriptEntries(entries)
+
+		// Assert
+		assert.Equal(t, 4000, summary.CacheCreationTokens)
+		assert.Equal(t, 0, summary.CacheTokens)
+		assert.Equal(t, 0, summary.InputTokens)
+	})
+
 	t.Run("user entries do not contribute tokens", func(t *testing.T) {
 		// Arrange: user entry with usage should not contribute.
 		entries := []TranscriptEntry{
@@ -632,6 +651,43 @@ func TestTokenAccumulation(t *testing.T) {
 // Tests for session timestamps
 // ---------------------------------------------------------------------------
 
+func TestCostExtraction(t *testing.T) {
+	t.Parallel()
+
+	t.Run("newest cost block wins", func(t *testing.T) {
+		// Arrange: cost values are running totals, so the last one is current.
+		entries := []TranscriptEntry{
+			{Type: "assistant", Cost: &EntryCost{TotalCostUSD: 0.5, TotalDurationMs: 1000, TotalLinesAdded: 10, TotalLinesRemoved: 1}},
+			makeUserTextEntry("next"),
+			{Type: "assistant", Cost: &EntryCost{TotalCostUSD: 1.25, TotalDurationMs: 5000, TotalLinesAdded: 120, TotalLinesRemoved: 8}},
+		}
+
+		// Act
+		summary := analyzeTranscriptEntries(entries)
+
+		// Assert
+		assert.Equal(t, 1.25, summary.TotalCostUSD)
+		assert.Equal(t, 5000, summary.TotalDurationMs)
+		assert.Equal(t, 120, summary.LinesAdded)
+		assert.Equal(t, 8, summary.LinesRemoved)
+	})
+
+	t.Run("cost field unmarshals from JSONL", func(t *testing.T) {
+		// Arrange
+		line := `{"type":"assistant","cost":{"total_cost_usd":0.0123,"total_duration_ms":42,"total_lines_added":3,"total_lines_removed":2}}`
+
+		// Act
+		var entry TranscriptEntry
+		err := json.Unmarshal([]byte(line), &entry)
+
+		// Assert
+		require.NoError(t, err)
+		require.NotNil(t, entry.Cost)
+		assert.Equal(t, 0.0123, entry.Cost.TotalCostUSD)
+		assert.Equal(t, 42, entry.Cost.TotalDurationMs)
+	})
+}
+
 func TestSessionTimestamps(t *testing.T) {
 	t.Parallel()
 
@@ -729,41 +785,32 @@ func TestParseTranscriptLastNLinesWithProjectPath_EmptyFile(t *testing.T) {
 	assert.Equal(t, 0, summary.InputTokens)
 }
 
-func TestParseTranscriptLastNLinesWithProjectPath_SingleUserMessage(t *testing.T) {
-	clearTranscriptCache()
-
+func TestParseTranscriptStream_SingleUserMessage(t *testing.T) {
 	content := `{"type":"user","message":{"role":"user","content":"hello"}}` + "\n"
-	f := writeTestTranscriptFile(t, content)
 
-	summary, err := ParseTranscriptLastNLinesWithProjectPath(f, 10, "")
+	summary, err := ParseTranscriptStream(strings.NewReader(content), 10)
 	require.NoError(t, err)
 	assert.NotNil(t, summary)
 }
 
-func TestParseTranscriptLastNLinesWithProjectPath_MultipleUserMessages(t *testing.T) {
-	clearTranscriptCache()
-
+func TestParseTranscriptStream_MultipleUserMessages(t *testing.T) {
 	content := `{"type":"user","message":{"role":"user","content":"first"}}
 {"type":"assistant","message":{"role":"assistant","content":"response"}}
 {"type":"user","message":{"role":"user","content":"second"}}
 {"type":"user","message":{"role":"user","content":"third"}}` + "\n"
-	f := writeTestTranscriptFile(t, content)
 
-	summary, err := ParseTranscriptLastNLinesWithProjectPath(f, 10, "")
+	summary, err := ParseTranscriptStream(strings.NewReader(content), 10)
 	require.NoError(t, err)
 	assert.NotNil(t, summary)
 }
 
-func TestParseTranscriptLastNLinesWithProjectPath_ToolResultNotUserMessage(t *testing.T) {
+func TestParseTranscriptStream_ToolResultNotUserMessage(t *testing.T) {
 	// tool_result has type "user" but message.content is an array, not a string.
 	// This means it is NOT a real user message boundary, so the current turn
 	// should include all entries (startIdx stays 0).
-	clearTranscriptCache()
-
 	content := `{"type":"user","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"123","content":"result"}]}}` + "\n"
-	f := writeTestTranscriptFile(t, content)
 
-	summary, err := ParseTranscriptLastNLinesWithProjectPath(f, 10, "")
+	summary, err := ParseTranscriptStream(strings.NewReader(content), 10)
 	require.NoError(t, err)
 	assert.NotNil(t, summary)
 }
@@ -808,38 +855,32 @@ func TestParseTranscriptLastNLinesWithProjectPath_TranscriptHasGitBranch(t *test
 }
 
 // ---------------------------------------------------------------------------
-// Tests for readCurrentTurnEntries — backward scan branches
+// Tests for ParseTranscriptStream — backward scan branches
 // ---------------------------------------------------------------------------
 
-func TestReadCurrentTurnEntries_InvalidJSONDuringScan(t *testing.T) {
+func TestParseTranscriptStream_InvalidJSONDuringScan(t *testing.T) {
 	// When a line contains "type":"user" but is not valid JSON,
 	// json.Unmarshal fails and the line is skipped during backward scan.
-	clearTranscriptCache()
-
 	content := `{"type":"user","message":{"role":"user","content":"second"}}
 {"type":"user","invalid-json-here
 {"type":"assistant","message":{"role":"assistant","content":"response"}}` + "\n"
-	f := writeTestTranscriptFile(t, content)
 
-	summary, err := ParseTranscriptLastNLinesWithProjectPath(f, 10, "")
+	summary, err := ParseTranscriptStream(strings.NewReader(content), 10)
 	require.NoError(t, err)
 	assert.NotNil(t, summary)
 }
 
-func TestReadCurrentTurnEntries_UserMessageFoundInBackwardScan(t *testing.T) {
+func TestParseTranscriptStream_UserMessageFoundInBackwardScan(t *testing.T) {
 	// When a real user message is found during backward scan, startIdx is set.
 	// Only entries from that point onward should be included in tool tracking.
-	clearTranscriptCache()
-
 	// Layout: old tool call, then user message, then new tool call + result.
 	// The backward scan should find the user message and set startIdx.
 	content := `{"type":"assistant","message":{"content":[{"type":"tool_use","id":"old-id","name":"Read"}]}}
 {"type":"user","message":{"role":"user","content":"do something new"}}
 {"type":"assistant","message":{"content":[{"type":"tool_use","id":"new-id","name":"Bash"}]}}
 {"type":"user","message":{"content":[{"type":"tool_result","tool_use_id":"new-id","content":"done"}]}}` + "\n"
-	f := writeTestTranscriptFile(t, content)
 
-	summary, err := ParseTranscriptLastNLinesWithProjectPath(f, 10, "")
+	summary, err := ParseTranscriptStream(strings.NewReader(content), 10)
 	require.NoError(t, err)
 	assert.NotNil(t, summary)
 	// Only the new tool (Bash) after the user message should be tracked.
@@ -849,6 +890,38 @@ func TestReadCurrentTurnEntries_UserMessageFoundInBackwardScan(t *testing.T) {
 		"New tool call after user message should be in current turn")
 }
 
+func TestParseTranscriptStream_KeepsLastNLines(t *testing.T) {
+	// Arrange: the Read result falls outside the last 2 lines
+	content := `{"type":"assistant","message":{"content":[{"type":"tool_use","id":"r1","name":"Read"}]}}
+{"type":"user","message":{"content":[{"type":"tool_result","tool_use_id":"r1"}]}}
+
+{"type":"assistant","message":{"content":[{"type":"tool_use","id":"b1","name":"Bash"}]}}
+{"type":"user","message":{"content":[{"type":"tool_result","tool_use_id":"b1"}]}}` + "\n"
+
+	// Act
+	tail, err := ParseTranscriptStream(strings.NewReader(content), 2)
+	require.NoError(t, err)
+	all, err := ParseTranscriptStream(strings.NewReader(content), 0)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, map[string]int{"Bash": 1}, tail.CompletedTools)
+	assert.Equal(t, map[string]int{"Read": 1, "Bash": 1}, all.CompletedTools)
+}
+
+func TestParseTranscriptStream_ReadError(t *testing.T) {
+	// Arrange
+	r := io.MultiReader(strings.NewReader(`{"type":"user"}`+"\n"), iotest.ErrReader(errors.New("disk gone")))
+
+	// Act
+	summary, err := ParseTranscriptStream(r, 10)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, summary)
+	assert.Contains(t, err.Error(), "disk gone")
+}
+
 // ---------------------------------------------------------------------------
 // Tests for analyzeTranscriptEntries — non-tool_result content item
 // ---------------------------------------------------------------------------
@@ -45,6 +45,18 @@ func TestGetSessionDuration(t *testing.T) {
 			end:      time.Date(2026, 1, 1, 12, 0, 30, 0, time.UTC),
 			expected: "30s",
 		},
+		{
+			name:     "sub-second duration rounds to the nearest second",
+			start:    time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC),
+			end:      time.Date(2026, 1, 1, 12, 0, 0, 600*int(time.Millisecond), time.UTC),
+			expected: "1s",
+		},
+		{
+			name:     "59.6 seconds rounds up to a minute",
+			start:    time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC),
+			end:      time.Date(2026, 1, 1, 12, 0, 59, 600*int(time.Millisecond), time.UTC),
+			expected: "1m",
+		},
 		{
 			name:     "exactly one minute shows 1m",
 			start:    time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC),
@@ -81,6 +93,12 @@ func TestGetSessionDuration(t *testing.T) {
 			end:      time.Date(2026, 1, 1, 14, 15, 0, 0, time.UTC),
 			expected: "4h15m",
 		},
+		{
+			name:     "overnight session switches to days",
+			start:    time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC),
+			end:      time.Date(2026, 1, 2, 11, 20, 0, 0, time.UTC),
+			expected: "1d 1h",
+		},
 	}
 
 	for _, tt := range tests {
@@ -446,6 +464,29 @@ func TestFormatCompletedTools(t *testing.T) {
 // GetProjectName
 // ---------------------------------------------------------------------------
 
+func TestFormatCostFromTranscript(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		summary  *TranscriptSummary
+		expected string
+	}{
+		{"nil summary", nil, ""},
+		{"zero cost omitted", &TranscriptSummary{LinesAdded: 5}, ""},
+		{"above one dollar", &TranscriptSummary{TotalCostUSD: 1.234}, "$1.23"},
+		{"below one dollar keeps four decimals", &TranscriptSummary{TotalCostUSD: 0.0123}, "$0.0123"},
+		{"with lines changed", &TranscriptSummary{TotalCostUSD: 2.5, LinesAdded: 1200, LinesRemoved: 108}, "$2.50 +1200/-108 lines"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, FormatCostFromTranscript(tt.summary))
+		})
+	}
+}
+
 func TestGetProjectName(t *testing.T) {
 	t.Parallel()
 
@@ -503,6 +544,18 @@ func TestGetProjectName(t *testing.T) {
 			projectDir: "",
 			expected:   "this-is-a-very-lo..",
 		},
+		{
+			name:       "multi-byte name is truncated by rune",
+			cwd:        "/home/user/中文项目名称中文项目名称中文项目名称中文项目名称",
+			projectDir: "",
+			expected:   "中文项目名称中文项目名称中文项目名..",
+		},
+		{
+			name:       "short multi-byte name is kept whole",
+			cwd:        "/home/user/我的中文项目",
+			projectDir: "",
+			expected:   "我的中文项目",
+		},
 		{
 			name:       "name exactly 20 chars is not truncated",
 			cwd:        "/home/user/exactly-twenty-ch",
@@ -527,6 +580,36 @@ func TestGetProjectName(t *testing.T) {
 			projectDir: "",
 			expected:   "parser",
 		},
+		{
+			name:       "WSL mount path",
+			cwd:        "/mnt/c/Users/dev/myproject",
+			projectDir: "",
+			expected:   "myproject",
+		},
+		{
+			name:       "mixed separators from a WSL worktree",
+			cwd:        `/mnt/c/Users/dev\worktrees\feature-x`,
+			projectDir: "",
+			expected:   "feature-x",
+		},
+		{
+			name:       "UNC path",
+			cwd:        `\\server\share\myproject`,
+			projectDir: "",
+			expected:   "myproject",
+		},
+		{
+			name:       "trailing backslash is ignored",
+			cwd:        `C:\Users\dev\myproject\`,
+			projectDir: "",
+			expected:   "myproject",
+		},
+		{
+			name:       "trailing slash is ignored",
+			cwd:        "/home/user/myproject/",
+			projectDir: "",
+			expected:   "myproject",
+		},
 	}
 
 	for _, tt := range tests {
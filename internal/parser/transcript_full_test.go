@@ -0,0 +1,397 @@
+package parser
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTranscript marshals entries into a JSONL file and returns its path.
+func writeTranscript(t *testing.T, entries []TranscriptEntry) string {
+	t.Helper()
+	var sb strings.Builder
+	for _, e := range entries {
+		raw, err := json.Marshal(e)
+		require.NoError(t, err)
+		sb.Write(raw)
+		sb.WriteByte('\n')
+	}
+	path := filepath.Join(t.TempDir(), "transcript.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(sb.String()), 0644))
+	return path
+}
+
+// TestParseTranscriptFull_AggregatesToolsAcrossTurns verifies tool counts
+// span every turn, where the tail parser only sees the current one.
+func TestParseTranscriptFull_AggregatesToolsAcrossTurns(t *testing.T) {
+	// Arrange
+	path := writeTranscript(t, []TranscriptEntry{
+		makeUserTextEntry("first"),
+		makeToolUseEntry("t1", "Read"),
+		makeToolResultEntry("t1", false),
+		makeToolUseEntry("t2", "Bash"),
+		makeToolResultEntry("t2", true),
+		makeUserTextEntry("second"),
+		makeToolUseEntry("t3", "Read"),
+		makeToolResultEntry("t3", false),
+	})
+
+	// Act
+	full, err := ParseTranscriptFull(path)
+	require.NoError(t, err)
+	tail, err := ParseTranscriptLastNLines(path, 100)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, 2, full.CompletedTools["Read"])
+	assert.Equal(t, 1, full.FailedTools["Bash"])
+	assert.Equal(t, 1, tail.CompletedTools["Read"], "tail parser stays scoped to the current turn")
+}
+
+// TestParseTranscriptFull_ActiveToolsScopedToCurrentTurn verifies a tool_use
+// left without a result in an earlier turn (e.g. interrupted) is not reported
+// as still running.
+func TestParseTranscriptFull_ActiveToolsScopedToCurrentTurn(t *testing.T) {
+	// Arrange
+	path := writeTranscript(t, []TranscriptEntry{
+		makeUserTextEntry("first"),
+		makeToolUseEntry("t1", "WebFetch"),
+		makeUserTextEntry("second"),
+		makeToolUseEntry("t2", "Grep"),
+	})
+
+	// Act
+	summary, err := ParseTranscriptFull(path)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Grep"}, summary.ActiveTools)
+}
+
+// TestParseTranscriptFull_LongLine verifies lines beyond bufio.Scanner's
+// 64 KB token limit (tool results embedding whole files) still parse.
+func TestParseTranscriptFull_LongLine(t *testing.T) {
+	// Arrange
+	big := makeUserTextEntry(strings.Repeat("x", 200*1024))
+	path := writeTranscript(t, []TranscriptEntry{
+		big,
+		makeToolUseEntry("t1", "Edit"),
+		makeToolResultEntry("t1", false),
+	})
+
+	// Act
+	summary, err := ParseTranscriptFull(path)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary.CompletedTools["Edit"])
+}
+
+func TestParseTranscriptFull_EmptyPath(t *testing.T) {
+	// Act
+	summary, err := ParseTranscriptFull("")
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotNil(t, summary)
+}
+
+func TestParseTranscriptFull_NonexistentFile(t *testing.T) {
+	// Act
+	_, err := ParseTranscriptFull(filepath.Join(t.TempDir(), "missing.jsonl"))
+
+	// Assert
+	require.Error(t, err)
+}
+
+// TestParseTranscriptFull_SummaryEntry verifies a compaction "summary" entry
+// resets ContextTokens to the post-compaction window, while the cumulative
+// token totals keep counting across it.
+func TestParseTranscriptFull_SummaryEntry(t *testing.T) {
+	// Arrange
+	path := filepath.Join("testdata", "compaction.jsonl")
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.SplitAfter(string(raw), "\n")
+	upToSummary := strings.Join(lines[:3], "") // user, 153K assistant, summary
+
+	// Act
+	atSummary, err := ParseTranscriptStream(strings.NewReader(upToSummary), 0)
+	require.NoError(t, err)
+	summary, err := ParseTranscriptFull(path)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Zero(t, atSummary.ContextTokens, "the summary entry drops the 153K peak")
+	assert.Equal(t, 1, summary.Compactions)
+	assert.Equal(t, 12+9000+14000+480, summary.ContextTokens, "context reflects the compacted window, not the 153K peak")
+	assert.Equal(t, 40+12, summary.InputTokens)
+	assert.Equal(t, 960+480, summary.OutputTokens)
+}
+
+// TestAnalyzeTranscriptEntries_TrailingSummaryEntry verifies that a
+// compaction with no assistant reply yet leaves no stale pre-compaction
+// context.
+func TestAnalyzeTranscriptEntries_TrailingSummaryEntry(t *testing.T) {
+	// Arrange
+	entries := []TranscriptEntry{
+		makeUserTextEntry("go"),
+		makeAssistantEntry(1000, 200, 150000, nil),
+		{Type: "summary"},
+	}
+
+	// Act
+	summary := analyzeTranscriptEntries(entries)
+
+	// Assert
+	assert.Equal(t, 1, summary.Compactions)
+	assert.Zero(t, summary.ContextTokens)
+	assert.Equal(t, 1000, summary.InputTokens)
+}
+
+// TestCountUserTurns verifies only real user messages count as turns;
+// tool_result submissions share type "user" but are not turns.
+func TestCountUserTurns(t *testing.T) {
+	// Arrange
+	path := writeTranscript(t, []TranscriptEntry{
+		makeUserTextEntry("first"),
+		makeToolUseEntry("t1", "Read"),
+		makeToolResultEntry("t1", false),
+		makeUserTextEntry("second"),
+		makeToolUseEntry("t2", "Bash"),
+		makeToolResultEntry("t2", false),
+		makeUserTextEntry("third"),
+	})
+
+	// Act
+	turns, err := CountUserTurns(path)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 3, turns)
+}
+
+// TestCountUserTurns_MissingFile verifies an empty path yields zero and a
+// missing file surfaces an error.
+func TestCountUserTurns_MissingFile(t *testing.T) {
+	turns, err := CountUserTurns("")
+	require.NoError(t, err)
+	assert.Equal(t, 0, turns)
+
+	_, err = CountUserTurns(filepath.Join(t.TempDir(), "missing.jsonl"))
+	assert.Error(t, err)
+}
+
+// TestParseTranscriptFull_Gzip verifies the full parser decompresses a
+// gzip-rotated transcript and aggregates its tools across turns.
+func TestParseTranscriptFull_Gzip(t *testing.T) {
+	// Arrange
+	plainPath := writeTranscript(t, []TranscriptEntry{
+		makeUserTextEntry("first"),
+		makeToolUseEntry("t1", "Read"),
+		makeToolResultEntry("t1", false),
+		makeUserTextEntry("second"),
+		makeToolUseEntry("t2", "Read"),
+		makeToolResultEntry("t2", true),
+	})
+	gzPath := gzipTranscript(t, plainPath, "transcript.jsonl.gz")
+
+	// Act
+	summary, err := ParseTranscriptFull(gzPath)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary.CompletedTools["Read"])
+	assert.Equal(t, 1, summary.FailedTools["Read"])
+}
+
+// TestCountUserTurns_Gzip verifies turns are counted in a transcript
+// compressed in place, detected by its gzip header rather than a suffix.
+func TestCountUserTurns_Gzip(t *testing.T) {
+	// Arrange
+	plainPath := writeTranscript(t, []TranscriptEntry{
+		makeUserTextEntry("first"),
+		makeToolUseEntry("t1", "Read"),
+		makeToolResultEntry("t1", false),
+		makeUserTextEntry("second"),
+	})
+	path := gzipTranscript(t, plainPath, "transcript.jsonl")
+
+	// Act
+	turns, err := CountUserTurns(path)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, turns)
+}
+
+// TestOpenTranscript_CorruptGzip verifies a .gz file without a valid gzip
+// header fails to open for the full parser and the turn counter.
+func TestOpenTranscript_CorruptGzip(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "transcript.jsonl.gz")
+	require.NoError(t, os.WriteFile(path, []byte("not gzip"), 0644))
+
+	// Act
+	_, fullErr := ParseTranscriptFull(path)
+	_, turnsErr := CountUserTurns(path)
+
+	// Assert
+	assert.Error(t, fullErr)
+	assert.Error(t, turnsErr)
+}
+
+// TestParseTranscriptLastNLines_LineCap verifies n caps the lines parsed
+// from the tail window, and that summaries cached for one cap are not served
+// for another.
+func TestParseTranscriptLastNLines_LineCap(t *testing.T) {
+	// Arrange
+	path := writeTranscript(t, []TranscriptEntry{
+		makeUserTextEntry("go"),
+		makeToolUseEntry("t1", "Read"),
+		makeToolResultEntry("t1", false),
+		makeToolUseEntry("t2", "Edit"),
+		makeToolResultEntry("t2", false),
+	})
+
+	// Act
+	capped, err := ParseTranscriptLastNLines(path, 2)
+	require.NoError(t, err)
+	all, err := ParseTranscriptLastNLines(path, 100)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, map[string]int{"Edit": 1}, capped.CompletedTools)
+	assert.Equal(t, map[string]int{"Read": 1, "Edit": 1}, all.CompletedTools)
+}
+
+// TestParseTranscriptLastNLines_Gzip verifies a gzip-rotated transcript is
+// decompressed and parsed like a plain one.
+func TestParseTranscriptLastNLines_Gzip(t *testing.T) {
+	// Arrange
+	plainPath := writeTranscript(t, []TranscriptEntry{
+		makeUserTextEntry("first"),
+		makeToolUseEntry("t1", "Read"),
+		makeToolResultEntry("t1", false),
+		makeUserTextEntry("second"),
+		makeToolUseEntry("t2", "Edit"),
+		makeToolResultEntry("t2", false),
+		makeToolUseEntry("t3", "Bash"),
+	})
+	gzPath := gzipTranscript(t, plainPath, "transcript.jsonl.gz")
+
+	// Act
+	summary, err := ParseTranscriptLastNLines(gzPath, 100)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary.CompletedTools["Edit"])
+	assert.Zero(t, summary.CompletedTools["Read"], "scoped to the current turn")
+	assert.Equal(t, []string{"Bash"}, summary.ActiveTools)
+}
+
+// TestParseTranscriptLastNLines_GzipMagicBytes verifies a transcript
+// compressed in place, without a .gz suffix, is detected by its header and
+// its token totals parse.
+func TestParseTranscriptLastNLines_GzipMagicBytes(t *testing.T) {
+	// Arrange
+	plainPath := writeTranscript(t, []TranscriptEntry{
+		makeUserTextEntry("go"),
+		makeAssistantEntry(100, 50, 2000, nil),
+		makeAssistantEntry(30, 20, 4000, nil),
+	})
+	path := gzipTranscript(t, plainPath, "transcript.jsonl")
+
+	// Act
+	summary, err := ParseTranscriptLastNLines(path, 100)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 130, summary.InputTokens)
+	assert.Equal(t, 70, summary.OutputTokens)
+	assert.Equal(t, 200, summary.TotalTokens)
+	assert.Equal(t, 6000, summary.CacheTokens)
+}
+
+// TestParseTranscriptLastNLines_GzipLargerThanTailWindow verifies a gzip
+// transcript that decompresses past the tail window parses the same as its
+// plain counterpart, which only reads the last window.
+func TestParseTranscriptLastNLines_GzipLargerThanTailWindow(t *testing.T) {
+	// Arrange
+	plainPath := writeTranscript(t, []TranscriptEntry{
+		makeUserTextEntry(strings.Repeat("x", 600*1024)),
+		makeToolUseEntry("t1", "Read"),
+		makeToolResultEntry("t1", false),
+		makeUserTextEntry("second"),
+		makeToolUseEntry("t2", "Edit"),
+		makeToolResultEntry("t2", false),
+		makeToolUseEntry("t3", "Bash"),
+	})
+	info, err := os.Stat(plainPath)
+	require.NoError(t, err)
+	require.Greater(t, info.Size(), int64(transcriptTailWindow))
+	gzPath := gzipTranscript(t, plainPath, "transcript.jsonl.gz")
+
+	// Act
+	summary, err := ParseTranscriptLastNLines(gzPath, 100)
+	require.NoError(t, err)
+	plain, err := ParseTranscriptLastNLines(plainPath, 100)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, 1, summary.CompletedTools["Edit"])
+	assert.Zero(t, summary.CompletedTools["Read"])
+	assert.Equal(t, []string{"Bash"}, summary.ActiveTools)
+	assert.Equal(t, plain.CompletedTools, summary.CompletedTools)
+}
+
+// TestReadTail verifies only the last window bytes are kept and the buffer
+// never grows past twice the window.
+func TestReadTail(t *testing.T) {
+	tail, err := readTail(strings.NewReader(strings.Repeat("a", 1000)+"0123456789"), 10)
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789", string(tail))
+	assert.LessOrEqual(t, cap(tail), 20)
+
+	short, err := readTail(strings.NewReader("abc"), 10)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", string(short))
+}
+
+// gzipTranscript compresses the file at src into a new temp file named name
+// and returns its path.
+func gzipTranscript(t *testing.T, src, name string) string {
+	t.Helper()
+	raw, err := os.ReadFile(src)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err = gz.Write(raw)
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0644))
+	return path
+}
+
+// TestParseTranscriptLastNLines_CorruptGzip verifies an unreadable .gz file
+// yields an empty summary rather than an error.
+func TestParseTranscriptLastNLines_CorruptGzip(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "transcript.jsonl.gz")
+	require.NoError(t, os.WriteFile(path, []byte("not gzip"), 0644))
+
+	// Act
+	summary, err := ParseTranscriptLastNLines(path, 100)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, summary.CompletedTools)
+}
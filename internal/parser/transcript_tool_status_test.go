@@ -2,7 +2,9 @@ package parser
 
 import (
 	"encoding/json"
+	"path/filepath"
 	"testing"
+	"time"
 )
 
 // makeToolUseEntry creates an assistant entry with a single tool_use content item.
@@ -169,3 +171,63 @@ func TestFailedToolsInitialized(t *testing.T) {
 		t.Error("FailedTools should be initialized, got nil")
 	}
 }
+
+// TestAwaitingApproval verifies a trailing unanswered tool_use is reported
+// once it has waited past approvalWaitThreshold, and not before.
+func TestAwaitingApproval(t *testing.T) {
+	base := time.Date(2026, 3, 17, 10, 0, 0, 0, time.UTC)
+	stale := makeToolUseEntry("id-2", "Bash")
+	stale.Timestamp = base.Format(time.RFC3339)
+
+	tests := []struct {
+		name    string
+		entries []TranscriptEntry
+		elapsed time.Duration
+		want    string
+	}{
+		{"trailing tool_use past threshold", []TranscriptEntry{makeToolUseEntry("id-1", "Read"), makeToolResultEntry("id-1", false), stale}, 10 * time.Second, "Bash"},
+		{"trailing tool_use still fresh", []TranscriptEntry{stale}, 2 * time.Second, ""},
+		{"tool_use answered", []TranscriptEntry{stale, makeToolResultEntry("id-2", false)}, time.Minute, ""},
+		{"no timestamp", []TranscriptEntry{makeToolUseEntry("id-3", "Bash")}, time.Minute, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			nowFn = func() time.Time { return base.Add(tt.elapsed) }
+			t.Cleanup(func() { nowFn = time.Now })
+
+			// Act
+			summary := analyzeTranscriptEntries(tt.entries)
+
+			// Assert
+			if summary.AwaitingApproval != tt.want {
+				t.Errorf("AwaitingApproval = %q, want %q", summary.AwaitingApproval, tt.want)
+			}
+		})
+	}
+}
+
+// TestActiveTools_RealShapedTranscript verifies, against a transcript in the
+// shape Claude Code writes, that tool_result blocks (which carry only a
+// tool_use_id, never a name) clear their tool_use so only the in-flight call
+// is reported as active.
+func TestActiveTools_RealShapedTranscript(t *testing.T) {
+	// Arrange
+	clearTranscriptCache()
+	path := filepath.Join("testdata", "tool_results.jsonl")
+
+	// Act
+	summary, err := ParseTranscriptLastNLines(path, 100)
+	if err != nil {
+		t.Fatalf("ParseTranscriptLastNLines() error = %v", err)
+	}
+
+	// Assert: Read completed, Bash is still running
+	if got := summary.CompletedTools["Read"]; got != 1 {
+		t.Errorf("CompletedTools[Read] = %d, want 1", got)
+	}
+	if got := FormatActiveTools(summary); got != "Bash" {
+		t.Errorf("FormatActiveTools() = %q, want %q", got, "Bash")
+	}
+}
@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
 
 	"github.com/young1lin/claude-token-monitor/internal/claudedir"
+	"github.com/young1lin/claude-token-monitor/internal/textutil"
 )
 
 // Config represents the statusline configuration
@@ -20,6 +22,12 @@ type Config struct {
 	Content ContentConfig `yaml:"content"`
 	Cache   CacheConfig   `yaml:"cache"`
 	Network NetworkConfig `yaml:"network"`
+	Git     GitConfig     `yaml:"git"`
+}
+
+// GitConfig controls the git cell
+type GitConfig struct {
+	ShowClean bool `yaml:"showClean"` // show "✓ clean" after the branch when the tree is clean
 }
 
 // NetworkConfig controls outbound network behavior.
@@ -48,18 +56,71 @@ type CacheConfig struct {
 
 // DisplayConfig controls what content is displayed
 type DisplayConfig struct {
-	SingleLine bool     `yaml:"singleLine"`
-	Show       []string `yaml:"show"`
-	Hide       []string `yaml:"hide"`
+	SingleLine   bool     `yaml:"singleLine"`
+	Show         []string `yaml:"show"`
+	Hide         []string `yaml:"hide"`
+	ShowDataAge  bool     `yaml:"showDataAge"`  // debug: show "(2s ago)" since the newest transcript entry
+	ShowApproval bool     `yaml:"showApproval"` // show "⏸ awaiting approval" for a stalled trailing tool call
+	Separator    string   `yaml:"separator"`    // single-line row separator (default " | ")
 }
 
+// DefaultSeparator joins rows in single-line mode
+const DefaultSeparator = " | "
+
 // FormatConfig controls formatting options
 type FormatConfig struct {
-	ProgressBar string `yaml:"progressBar"` // "ascii" or "braille"
-	TimeFormat  string `yaml:"timeFormat"`  // "12h" or "24h"
-	Compact     bool   `yaml:"compact"`
+	ProgressBar     string `yaml:"progressBar"` // "block", "braille", "ascii" or "gradient"
+	TimeFormat      string `yaml:"timeFormat"`  // "12h" or "24h"
+	Compact         bool   `yaml:"compact"`
+	ShowCacheTokens bool   `yaml:"showCacheTokens"` // annotate token-info with the cache-read share
+
+	// Currency is the ISO 4217 code costs are displayed in (default "USD").
+	// ExchangeRate converts USD into that currency and is static, supplied by
+	// the user — nothing is fetched. Ignored (treated as 1) when <= 0.
+	Currency     string  `yaml:"currency"`
+	ExchangeRate float64 `yaml:"exchangeRate"`
+
+	// DurationCapDays collapses durations longer than this many days to
+	// "<n>d+". 0 (default) shows the full "3d 4h" form.
+	DurationCapDays int `yaml:"durationCapDays"`
+
+	// TurnLimit is a soft cap on user turns per session. When > 0 the
+	// statusline shows "💬 18/50"; 0 (default) hides the counter and skips
+	// the full-transcript scan it needs.
+	TurnLimit int `yaml:"turnLimit"`
+
+	// Thresholds sets the context percentages where the token bar turns
+	// yellow (warning) and red (critical). See GetThresholds.
+	Thresholds ThresholdsConfig `yaml:"thresholds"`
+
+	// BarWidth is the token bar width in cells (default 10, clamped to
+	// 4-40). STATUSLINE_BAR_WIDTH overrides it.
+	BarWidth int `yaml:"barWidth"`
+
+	// Ellipsis marks truncated names and descriptions: "..", "…", "..." or
+	// any custom string. Empty uses "..".
+	Ellipsis string `yaml:"ellipsis"`
 }
 
+// Token bar width bounds, in cells
+const (
+	DefaultBarWidth = 10
+	MinBarWidth     = 4
+	MaxBarWidth     = 40
+)
+
+// ThresholdsConfig holds the token bar colour breakpoints, in percent
+type ThresholdsConfig struct {
+	Warning  int `yaml:"warning"`
+	Critical int `yaml:"critical"`
+}
+
+// Default token bar colour breakpoints, in percent of the context window
+const (
+	DefaultWarningThreshold  = 60
+	DefaultCriticalThreshold = 75
+)
+
 // ContentConfig controls content composition
 type ContentConfig struct {
 	Composers []ComposerConfig  `yaml:"composers"`
@@ -120,12 +181,24 @@ func loadFile(path string) (*Config, error) {
 	}
 
 	cfg := DefaultConfig()
+	defaultHide := cfg.Display.Hide
+	cfg.Display.Hide = nil
 	if err := yaml.Unmarshal(data, cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
+	// The default hide list adds to the user's own; listing an item under
+	// display.show opts back in to it.
+	for _, h := range defaultHide {
+		if !slices.Contains(cfg.Display.Show, h) && !slices.Contains(cfg.Display.Hide, h) {
+			cfg.Display.Hide = append(cfg.Display.Hide, h)
+		}
+	}
+
 	// Validate format options
-	if cfg.Format.ProgressBar != "" && cfg.Format.ProgressBar != "ascii" && cfg.Format.ProgressBar != "braille" {
+	switch cfg.Format.ProgressBar {
+	case "", "block", "braille", "ascii", "gradient":
+	default:
 		cfg.Format.ProgressBar = "braille" // Default to braille
 	}
 	if cfg.Format.TimeFormat != "" && cfg.Format.TimeFormat != "12h" && cfg.Format.TimeFormat != "24h" {
@@ -152,7 +225,7 @@ func DefaultConfig() *Config {
 		Display: DisplayConfig{
 			SingleLine: false,
 			Show:       nil,
-			Hide:       nil,
+			Hide:       []string{"claude-version"}, // opt in via display.show
 		},
 		Format: FormatConfig{
 			ProgressBar: "braille",
@@ -221,6 +294,32 @@ func (c *Config) IsSingleLine() bool {
 	return c.Display.SingleLine
 }
 
+// GetSeparator returns the single-line row separator, DefaultSeparator when
+// unset
+func (c *Config) GetSeparator() string {
+	if c.Display.Separator == "" {
+		return DefaultSeparator
+	}
+	return c.Display.Separator
+}
+
+// ShowDataAge returns true if the data-age debug element is enabled in YAML.
+// STATUSLINE_DEBUG=1 enables it independently — see main.
+func (c *Config) ShowDataAge() bool {
+	return c.Display.ShowDataAge
+}
+
+// ShowApproval returns true if the pending-approval element is enabled. Off
+// by default: the signal is a timing heuristic that slow commands also trip.
+func (c *Config) ShowApproval() bool {
+	return c.Display.ShowApproval
+}
+
+// ShowGitClean returns true if a clean working tree should be marked explicitly
+func (c *Config) ShowGitClean() bool {
+	return c.Git.ShowClean
+}
+
 // GetProgressBarStyle returns the progress bar style
 func (c *Config) GetProgressBarStyle() string {
 	if c.Format.ProgressBar == "" {
@@ -242,6 +341,86 @@ func (c *Config) IsCompact() bool {
 	return c.Format.Compact
 }
 
+// ShowCacheTokens returns true if the token-info cell should annotate how
+// much of the context is served from cache reads
+func (c *Config) ShowCacheTokens() bool {
+	return c.Format.ShowCacheTokens
+}
+
+// GetCurrency returns the display currency code (upper-cased, default "USD")
+// and the USD→currency exchange rate. USD always uses a rate of 1; any other
+// currency without a positive rate also falls back to 1 (no conversion).
+func (c *Config) GetCurrency() (string, float64) {
+	code := strings.ToUpper(strings.TrimSpace(c.Format.Currency))
+	if code == "" || code == "USD" {
+		return "USD", 1
+	}
+	if c.Format.ExchangeRate <= 0 {
+		return code, 1
+	}
+	return code, c.Format.ExchangeRate
+}
+
+// GetDurationCapDays returns the day cap for durations; 0 means no cap
+func (c *Config) GetDurationCapDays() int {
+	if c.Format.DurationCapDays < 0 {
+		return 0
+	}
+	return c.Format.DurationCapDays
+}
+
+// GetThresholds returns the warning and critical context percentages. A
+// missing (zero) value takes its default; if the resulting pair is out of
+// the 0-100 range or warning is not below critical, both fall back to the
+// defaults so a typo can't leave the bar permanently red or never red. The
+// keys are independent: critical alone does not move warning.
+func (c *Config) GetThresholds() (warning, critical int) {
+	warning, critical = c.Format.Thresholds.Warning, c.Format.Thresholds.Critical
+	if warning == 0 {
+		warning = DefaultWarningThreshold
+	}
+	if critical == 0 {
+		critical = DefaultCriticalThreshold
+	}
+	if warning < 0 || critical > 100 || warning >= critical {
+		return DefaultWarningThreshold, DefaultCriticalThreshold
+	}
+	return warning, critical
+}
+
+// GetBarWidth returns the token bar width. Unset (0) or negative values use
+// DefaultBarWidth; anything else is clamped to [MinBarWidth, MaxBarWidth]
+// so a typo can't produce a useless or line-wrapping bar.
+func (c *Config) GetBarWidth() int {
+	switch w := c.Format.BarWidth; {
+	case w <= 0:
+		return DefaultBarWidth
+	case w < MinBarWidth:
+		return MinBarWidth
+	case w > MaxBarWidth:
+		return MaxBarWidth
+	default:
+		return w
+	}
+}
+
+// GetEllipsis returns the truncation marker, textutil.DefaultEllipsis when
+// unset
+func (c *Config) GetEllipsis() string {
+	if c.Format.Ellipsis == "" {
+		return textutil.DefaultEllipsis
+	}
+	return c.Format.Ellipsis
+}
+
+// GetTurnLimit returns the soft per-session turn limit; 0 means disabled
+func (c *Config) GetTurnLimit() int {
+	if c.Format.TurnLimit < 0 {
+		return 0
+	}
+	return c.Format.TurnLimit
+}
+
 // GetComposerOverride returns the composer to use for a given content type
 // Returns empty string if no override is specified
 func (c *Config) GetComposerOverride(contentType string) string {
@@ -25,8 +25,8 @@ func TestDefaultConfig(t *testing.T) {
 		t.Error("Default Show should be nil")
 	}
 
-	if cfg.Display.Hide != nil {
-		t.Error("Default Hide should be nil")
+	if len(cfg.Display.Hide) != 1 || cfg.Display.Hide[0] != "claude-version" {
+		t.Errorf("Default Hide should be [claude-version], got %v", cfg.Display.Hide)
 	}
 
 	if cfg.Format.ProgressBar != "braille" {
@@ -169,6 +169,25 @@ format:
 			wantTime:     "12h",
 			wantCompact:  true,
 		},
+		{
+			name: "user hide merges with default hide",
+			configYAML: `
+display:
+  hide:
+    - memory-files
+`,
+			wantHide: []string{"memory-files", "claude-version"},
+		},
+		{
+			name: "show opts back in to default hidden item",
+			configYAML: `
+display:
+  show:
+    - claude-version
+`,
+			wantShow: []string{"claude-version"},
+			wantHide: []string{},
+		},
 		{
 			name: "invalid progress bar falls back to default",
 			configYAML: `
@@ -177,6 +196,22 @@ format:
 `,
 			wantProgress: "braille",
 		},
+		{
+			name: "block progress bar is accepted",
+			configYAML: `
+format:
+  progressBar: block
+`,
+			wantProgress: "block",
+		},
+		{
+			name: "gradient progress bar is accepted",
+			configYAML: `
+format:
+  progressBar: gradient
+`,
+			wantProgress: "gradient",
+		},
 		{
 			name: "invalid time format falls back to default",
 			configYAML: `
@@ -188,6 +223,7 @@ format:
 		{
 			name:         "empty config uses defaults",
 			configYAML:   `{}`,
+			wantHide:     []string{"claude-version"},
 			wantProgress: "braille",
 			wantTime:     "24h",
 		},
@@ -316,6 +352,13 @@ func TestGetProgressBarStyle(t *testing.T) {
 		cfg  *Config
 		want string
 	}{
+		{
+			name: "block style",
+			cfg: &Config{
+				Format: FormatConfig{ProgressBar: "block"},
+			},
+			want: "block",
+		},
 		{
 			name: "braille style",
 			cfg: &Config{
@@ -330,6 +373,13 @@ func TestGetProgressBarStyle(t *testing.T) {
 			},
 			want: "ascii",
 		},
+		{
+			name: "gradient style",
+			cfg: &Config{
+				Format: FormatConfig{ProgressBar: "gradient"},
+			},
+			want: "gradient",
+		},
 		{
 			name: "empty defaults to braille",
 			cfg: &Config{
@@ -588,6 +638,28 @@ func TestIsCompact(t *testing.T) {
 	})
 }
 
+func TestShowCacheTokens(t *testing.T) {
+	t.Run("enabled via yaml", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "statusline.yaml")
+		if err := os.WriteFile(path, []byte("format:\n  showCacheTokens: true\n"), 0644); err != nil {
+			t.Fatalf("failed to write config: %v", err)
+		}
+
+		cfg, err := loadFile(path)
+		if err != nil {
+			t.Fatalf("loadFile() error = %v", err)
+		}
+		if !cfg.ShowCacheTokens() {
+			t.Error("expected true")
+		}
+	})
+	t.Run("disabled by default", func(t *testing.T) {
+		if DefaultConfig().ShowCacheTokens() {
+			t.Error("expected false")
+		}
+	})
+}
+
 func TestLoadFileWithComposerConfig(t *testing.T) {
 	tempDir := t.TempDir()
 
@@ -1070,3 +1142,239 @@ func TestLoad_YAMLAndYMLBothSupported(t *testing.T) {
 		}
 	})
 }
+
+func TestGetCurrency(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency string
+		rate     float64
+		wantCode string
+		wantRate float64
+	}{
+		{"default is USD", "", 0, "USD", 1},
+		{"USD ignores rate", "usd", 7.1, "USD", 1},
+		{"EUR with rate", "EUR", 0.92, "EUR", 0.92},
+		{"code is upper-cased", " jpy ", 150, "JPY", 150},
+		{"missing rate means no conversion", "EUR", 0, "EUR", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Format: FormatConfig{Currency: tt.currency, ExchangeRate: tt.rate}}
+			code, rate := cfg.GetCurrency()
+			if code != tt.wantCode || rate != tt.wantRate {
+				t.Errorf("GetCurrency() = (%q, %v), want (%q, %v)", code, rate, tt.wantCode, tt.wantRate)
+			}
+		})
+	}
+}
+
+func TestShowGitClean(t *testing.T) {
+	t.Run("true", func(t *testing.T) {
+		cfg := &Config{Git: GitConfig{ShowClean: true}}
+		if !cfg.ShowGitClean() {
+			t.Error("expected true")
+		}
+	})
+	t.Run("default false", func(t *testing.T) {
+		if DefaultConfig().ShowGitClean() {
+			t.Error("expected false")
+		}
+	})
+}
+
+func TestGetDurationCapDays(t *testing.T) {
+	tests := []struct {
+		name string
+		days int
+		want int
+	}{
+		{"default no cap", 0, 0},
+		{"positive", 3, 3},
+		{"negative disables", -2, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Format: FormatConfig{DurationCapDays: tt.days}}
+			if got := cfg.GetDurationCapDays(); got != tt.want {
+				t.Errorf("GetDurationCapDays() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetTurnLimit(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit int
+		want  int
+	}{
+		{"default disabled", 0, 0},
+		{"positive", 50, 50},
+		{"negative disables", -1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Format: FormatConfig{TurnLimit: tt.limit}}
+			if got := cfg.GetTurnLimit(); got != tt.want {
+				t.Errorf("GetTurnLimit() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetThresholds(t *testing.T) {
+	tests := []struct {
+		name         string
+		thresholds   ThresholdsConfig
+		wantWarning  int
+		wantCritical int
+	}{
+		{"defaults when missing", ThresholdsConfig{}, 60, 75},
+		{"both set", ThresholdsConfig{Warning: 50, Critical: 80}, 50, 80},
+		{"only critical", ThresholdsConfig{Critical: 90}, 60, 90},
+		{"only warning", ThresholdsConfig{Warning: 40}, 40, 75},
+		{"warning not below critical falls back", ThresholdsConfig{Warning: 80, Critical: 70}, 60, 75},
+		{"equal values fall back", ThresholdsConfig{Warning: 70, Critical: 70}, 60, 75},
+		{"critical above 100 falls back", ThresholdsConfig{Warning: 50, Critical: 120}, 60, 75},
+		{"negative warning falls back", ThresholdsConfig{Warning: -10, Critical: 80}, 60, 75},
+		{"only warning past default critical falls back", ThresholdsConfig{Warning: 80}, 60, 75},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Format: FormatConfig{Thresholds: tt.thresholds}}
+			warning, critical := cfg.GetThresholds()
+			if warning != tt.wantWarning || critical != tt.wantCritical {
+				t.Errorf("GetThresholds() = (%d, %d), want (%d, %d)", warning, critical, tt.wantWarning, tt.wantCritical)
+			}
+		})
+	}
+}
+
+func TestLoadThresholdsFromYAML(t *testing.T) {
+	dir := t.TempDir()
+	claudeDir := filepath.Join(dir, ".claude")
+	if err := os.MkdirAll(claudeDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	yaml := "format:\n  thresholds:\n    warning: 50\n    critical: 90\n"
+	if err := os.WriteFile(filepath.Join(claudeDir, "statusline.yml"), []byte(yaml), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	warning, critical := cfg.GetThresholds()
+	if warning != 50 || critical != 90 {
+		t.Errorf("GetThresholds() = (%d, %d), want (50, 90)", warning, critical)
+	}
+}
+
+func TestGetBarWidth(t *testing.T) {
+	tests := []struct {
+		name  string
+		width int
+		want  int
+	}{
+		{"default when unset", 0, 10},
+		{"negative uses default", -5, 10},
+		{"in range", 24, 24},
+		{"lower bound", 4, 4},
+		{"upper bound", 40, 40},
+		{"clamped up to min", 2, 4},
+		{"clamped down to max", 120, 40},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Format: FormatConfig{BarWidth: tt.width}}
+			if got := cfg.GetBarWidth(); got != tt.want {
+				t.Errorf("GetBarWidth() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetEllipsis(t *testing.T) {
+	tests := []struct {
+		name     string
+		ellipsis string
+		want     string
+	}{
+		{"default when unset", "", ".."},
+		{"single char", "…", "…"},
+		{"custom", " [+]", " [+]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Format: FormatConfig{Ellipsis: tt.ellipsis}}
+			if got := cfg.GetEllipsis(); got != tt.want {
+				t.Errorf("GetEllipsis() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadSeparatorFromYAML(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{"custom dot", "display:\n  separator: \" · \"\n", " · "},
+		{"tab", "display:\n  separator: \"\\t\"\n", "\t"},
+		{"empty falls back", "display:\n  separator: \"\"\n", " | "},
+		{"unset uses default", "display:\n  singleLine: true\n", " | "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "statusline.yaml")
+			if err := os.WriteFile(path, []byte(tt.yaml), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := loadFile(path)
+			if err != nil {
+				t.Fatalf("loadFile() error = %v", err)
+			}
+			if got := cfg.GetSeparator(); got != tt.want {
+				t.Errorf("GetSeparator() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadShowApprovalFromYAML(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want bool
+	}{
+		{"enabled", "display:\n  showApproval: true\n", true},
+		{"unset defaults to off", "display:\n  singleLine: true\n", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "statusline.yaml")
+			if err := os.WriteFile(path, []byte(tt.yaml), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := loadFile(path)
+			if err != nil {
+				t.Fatalf("loadFile() error = %v", err)
+			}
+			if got := cfg.ShowApproval(); got != tt.want {
+				t.Errorf("ShowApproval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
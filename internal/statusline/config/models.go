@@ -0,0 +1,85 @@
+package config
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DefaultContextWindow is the context window assumed for models missing from
+// the table below — the standard Anthropic 200K window.
+const DefaultContextWindow = 200_000
+
+// extendedContextSuffix marks Claude Code model IDs running with the 1M
+// context beta, e.g. "claude-sonnet-4-5[1m]".
+const extendedContextSuffix = "[1m]"
+
+// bedrockProviderPrefix precedes the model name in Bedrock IDs, after an
+// optional cross-region inference prefix: "us.anthropic.claude-…".
+const bedrockProviderPrefix = "anthropic."
+
+// bedrockVersionSuffix matches the model version Bedrock appends: "-v1:0".
+var bedrockVersionSuffix = regexp.MustCompile(`-v\d+(:\d+)?$`)
+
+// contextWindows maps model ID prefixes to their context window size. Order
+// matters: the first matching prefix wins, so list more specific prefixes
+// before broader ones.
+var contextWindows = []struct {
+	prefix string
+	tokens int
+}{
+	// Anthropic
+	{"claude-", 200_000},
+
+	// Zhipu / Z.ai GLM (seen in transcripts when Claude Code points at the
+	// GLM Coding Plan)
+	{"glm-4.5-air", 128_000},
+	{"glm-4.5", 128_000},
+	{"glm-4.6", 200_000},
+	{"glm-4.7", 200_000},
+
+	// DeepSeek Anthropic-compatible endpoint
+	{"deepseek-", 128_000},
+}
+
+// NormalizeModelID maps provider-specific model IDs onto the Anthropic form
+// the table above is keyed by, lower-cased:
+//
+//   - Bedrock "us.anthropic.claude-sonnet-4-5-20250929-v1:0", bare or inside
+//     an inference-profile ARN → "claude-sonnet-4-5-20250929"
+//   - Vertex "claude-sonnet-4-5@20250929" → "claude-sonnet-4-5"
+//
+// A trailing "[1m]" is kept. Other IDs only get trimmed and lower-cased.
+func NormalizeModelID(modelID string) string {
+	id := strings.ToLower(strings.TrimSpace(modelID))
+	suffix := ""
+	if strings.HasSuffix(id, extendedContextSuffix) {
+		id, suffix = strings.TrimSuffix(id, extendedContextSuffix), extendedContextSuffix
+	}
+	if i := strings.LastIndex(id, "/"); i >= 0 {
+		id = id[i+1:]
+	}
+	if i := strings.Index(id, bedrockProviderPrefix); i >= 0 {
+		id = bedrockVersionSuffix.ReplaceAllString(id[i+len(bedrockProviderPrefix):], "")
+	}
+	if i := strings.Index(id, "@"); i >= 0 {
+		id = id[:i]
+	}
+	return id + suffix
+}
+
+// GetContextWindow returns the context window size, in tokens, for a model
+// ID as reported on stdin or in the transcript. Bedrock and Vertex IDs are
+// normalized first (see NormalizeModelID) and matching is case-insensitive.
+// Unknown models get DefaultContextWindow.
+func GetContextWindow(modelID string) int {
+	id := NormalizeModelID(modelID)
+	if strings.HasSuffix(id, extendedContextSuffix) {
+		return 1_000_000
+	}
+	for _, w := range contextWindows {
+		if strings.HasPrefix(id, w.prefix) {
+			return w.tokens
+		}
+	}
+	return DefaultContextWindow
+}
@@ -0,0 +1,60 @@
+package config
+
+import "testing"
+
+func TestGetContextWindow(t *testing.T) {
+	tests := []struct {
+		modelID string
+		want    int
+	}{
+		{"claude-opus-4-1-20250805", 200_000},
+		{"claude-sonnet-4-5[1m]", 1_000_000},
+		{"Claude-Sonnet-4-5[1M]", 1_000_000},
+		{"glm-4.5-air", 128_000},
+		{"glm-4.5", 128_000},
+		{"GLM-4.6", 200_000},
+		{"glm-4.7", 200_000},
+		{"deepseek-chat", 128_000},
+		{"us.anthropic.claude-sonnet-4-5-20250929-v1:0[1m]", 1_000_000},
+		{"claude-sonnet-4-5@20250929", 200_000},
+		{"", DefaultContextWindow},
+		{"some-unknown-model", DefaultContextWindow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.modelID, func(t *testing.T) {
+			if got := GetContextWindow(tt.modelID); got != tt.want {
+				t.Errorf("GetContextWindow(%q) = %d, want %d", tt.modelID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeModelID(t *testing.T) {
+	tests := []struct {
+		name    string
+		modelID string
+		want    string
+	}{
+		{"anthropic", "claude-sonnet-4-5-20250929", "claude-sonnet-4-5-20250929"},
+		{"anthropic mixed case", " Claude-Opus-4-1 ", "claude-opus-4-1"},
+		{"bedrock", "anthropic.claude-sonnet-4-5-20250929-v1:0", "claude-sonnet-4-5-20250929"},
+		{"bedrock cross-region", "us.anthropic.claude-opus-4-1-20250805-v1:0", "claude-opus-4-1-20250805"},
+		{"bedrock global", "global.anthropic.claude-sonnet-4-5-20250929-v1:0", "claude-sonnet-4-5-20250929"},
+		{"bedrock version without revision", "anthropic.claude-3-haiku-20240307-v1", "claude-3-haiku-20240307"},
+		{"bedrock ARN", "arn:aws:bedrock:us-east-1:123456789012:inference-profile/us.anthropic.claude-sonnet-4-5-20250929-v1:0", "claude-sonnet-4-5-20250929"},
+		{"bedrock 1M", "us.anthropic.claude-sonnet-4-5-20250929-v1:0[1m]", "claude-sonnet-4-5-20250929[1m]"},
+		{"vertex", "claude-sonnet-4-5@20250929", "claude-sonnet-4-5"},
+		{"vertex 1M", "claude-sonnet-4-5@20250929[1m]", "claude-sonnet-4-5[1m]"},
+		{"other provider untouched", "glm-4.6", "glm-4.6"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeModelID(tt.modelID); got != tt.want {
+				t.Errorf("NormalizeModelID(%q) = %q, want %q", tt.modelID, got, tt.want)
+			}
+		})
+	}
+}
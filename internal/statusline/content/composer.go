@@ -5,10 +5,21 @@ package content
 import (
 	"bytes"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 	"text/template"
 )
 
+// executeTemplate runs a parsed template; a var so tests can simulate a
+// panicking execution.
+var executeTemplate = func(tmpl *template.Template, w io.Writer, data interface{}) error {
+	return tmpl.Execute(w, data)
+}
+
+// composerPanicLog receives a line for each recovered template panic.
+var composerPanicLog io.Writer = os.Stderr
+
 // Composer combines multiple content types into a single output
 type Composer interface {
 	// Name returns the unique identifier for this composer
@@ -54,12 +65,21 @@ func (c *BaseComposer) InputTypes() []ContentType {
 	return c.inputTypes
 }
 
-// Compose executes the template with the provided contents
-func (c *BaseComposer) Compose(contents map[ContentType]string) string {
+// Compose executes the template with the provided contents. A panic during
+// execution is logged and degrades to fallbackCompose rather than taking the
+// whole statusline down.
+func (c *BaseComposer) Compose(contents map[ContentType]string) (result string) {
 	if c.template == "" {
 		return ""
 	}
 
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Fprintf(composerPanicLog, "composer %q: template panic: %v\n", c.name, r)
+			result = c.fallbackCompose(contents)
+		}
+	}()
+
 	// Build template data map with proper Go template key format
 	// Go templates require keys to be valid identifiers (no hyphens)
 	data := make(map[string]interface{})
@@ -80,7 +100,7 @@ func (c *BaseComposer) Compose(contents map[ContentType]string) string {
 	}
 
 	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
+	if err := executeTemplate(tmpl, &buf, data); err != nil {
 		return c.fallbackCompose(contents)
 	}
 
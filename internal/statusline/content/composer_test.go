@@ -2,7 +2,11 @@
 package content
 
 import (
+	"bytes"
+	"io"
+	"strings"
 	"testing"
+	"text/template"
 )
 
 func TestBaseComposer_Compose(t *testing.T) {
@@ -653,6 +657,44 @@ func TestBaseComposer_ExecuteError(t *testing.T) {
 	}
 }
 
+func TestBaseComposer_NilMethodCallFallsBack(t *testing.T) {
+	// .Nonexistent on an absent (nil) input must fall back, not crash.
+	composer := NewBaseComposer("nil-method", []ContentType{
+		ContentModel,
+		ContentTokenBar,
+	}, "{{.missing.Nonexistent}} {{.model}}")
+
+	got := composer.Compose(map[ContentType]string{
+		ContentModel:    "GLM-4.7",
+		ContentTokenBar: "██░░",
+	})
+	if got != "GLM-4.7 ██░░" {
+		t.Errorf("nil method call fallback = %q, want %q", got, "GLM-4.7 ██░░")
+	}
+}
+
+func TestBaseComposer_RecoversFromPanic(t *testing.T) {
+	origExecute, origLog := executeTemplate, composerPanicLog
+	defer func() { executeTemplate, composerPanicLog = origExecute, origLog }()
+	executeTemplate = func(*template.Template, io.Writer, interface{}) error {
+		var p *struct{ Name string }
+		_ = p.Name // nil pointer dereference
+		return nil
+	}
+	var logBuf bytes.Buffer
+	composerPanicLog = &logBuf
+
+	composer := NewBaseComposer("panicky", []ContentType{ContentModel}, "{{.model}}")
+	got := composer.Compose(map[ContentType]string{ContentModel: "GLM-4.7"})
+
+	if got != "GLM-4.7" {
+		t.Errorf("panic fallback = %q, want %q", got, "GLM-4.7")
+	}
+	if !strings.Contains(logBuf.String(), `composer "panicky": template panic`) {
+		t.Errorf("panic not logged, got %q", logBuf.String())
+	}
+}
+
 func TestConditionalComposer_RuntimeParseFail(t *testing.T) {
 	// Test the pattern where Format is empty after match:
 	composer := NewConditionalComposer("runtime-fail", []ContentType{
@@ -0,0 +1,141 @@
+package content
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// currencySymbols maps ISO 4217 codes to their display symbol. Codes not in
+// the table render with the code itself as a prefix, e.g. "CHF 1.23".
+var currencySymbols = map[string]string{
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"JPY": "¥",
+	"CNY": "¥",
+	"KRW": "₩",
+	"INR": "₹",
+	"RUB": "₽",
+	"BRL": "R$",
+	"CAD": "C$",
+	"AUD": "A$",
+}
+
+// zeroDecimalCurrencies are shown without a fractional part — nobody reads a
+// JPY cost as "¥153.27".
+var zeroDecimalCurrencies = map[string]bool{
+	"JPY": true,
+	"KRW": true,
+}
+
+// displayCurrency is the currency all cost output is converted into. Defaults
+// to USD with no conversion; main replaces it via SetCurrency once the YAML
+// config is loaded.
+var (
+	displayCurrency     = "USD"
+	displayExchangeRate = 1.0
+	displayCurrencyMu   sync.RWMutex
+)
+
+// SetCurrency configures the display currency and the static USD→currency
+// exchange rate. An empty code resets to USD; a non-positive rate is treated
+// as 1. Thread-safe.
+func SetCurrency(code string, rate float64) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		code = "USD"
+	}
+	if rate <= 0 {
+		rate = 1
+	}
+	displayCurrencyMu.Lock()
+	defer displayCurrencyMu.Unlock()
+	displayCurrency = code
+	displayExchangeRate = rate
+}
+
+// getCurrency returns the configured display currency and exchange rate.
+func getCurrency() (string, float64) {
+	displayCurrencyMu.RLock()
+	defer displayCurrencyMu.RUnlock()
+	return displayCurrency, displayExchangeRate
+}
+
+// formatCost converts a USD amount into the display currency and formats it
+// with the matching symbol and two decimals. Every cost shown by the
+// statusline goes through here (or formatCostPrecise) so the currency
+// setting applies uniformly.
+func formatCost(usd float64) string {
+	return formatCostDecimals(usd, false)
+}
+
+// formatCostPrecise is formatCost with four decimals for amounts below one
+// unit, so early-session costs like $0.0042 don't collapse to "$0.00".
+func formatCostPrecise(usd float64) string {
+	return formatCostDecimals(usd, true)
+}
+
+// FormatCost formats a USD amount the way the cost segment shows it, in the
+// display currency and without the icon, e.g. "$1.25".
+func FormatCost(usd float64) string {
+	return formatCostPrecise(usd)
+}
+
+func formatCostDecimals(usd float64, precise bool) string {
+	code, rate := getCurrency()
+	amount := usd * rate
+
+	symbol, ok := currencySymbols[code]
+	if !ok {
+		symbol = code + " "
+	}
+	switch {
+	case zeroDecimalCurrencies[code]:
+		return fmt.Sprintf("%s%.0f", symbol, amount)
+	case precise && amount < 1:
+		return fmt.Sprintf("%s%.4f", symbol, amount)
+	default:
+		return fmt.Sprintf("%s%.2f", symbol, amount)
+	}
+}
+
+// costSegment renders input's stdin cost block as "💰 $0.0123 +1200/-108 lines"
+// (lines only when any changed), or "" when the reported cost is zero.
+// Shared by the cost collector and session-total so both read the same.
+func costSegment(input *StatusLineInput) string {
+	cost := input.Cost
+	if cost.TotalCostUSD <= 0 {
+		return ""
+	}
+	line := "💰 " + formatCostPrecise(cost.TotalCostUSD)
+	if cost.TotalLinesAdded > 0 || cost.TotalLinesRemoved > 0 {
+		line += fmt.Sprintf(" +%d/-%d lines", cost.TotalLinesAdded, cost.TotalLinesRemoved)
+	}
+	return line
+}
+
+// CostCollector renders the session cost Claude Code reports on stdin, plus
+// the lines-changed delta when there is one: "💰 $0.0123 +1200/-108 lines".
+// The default layout shows the same segment at the front of session-total;
+// this collector exposes it on its own so composers can reference it.
+type CostCollector struct {
+	*BaseCollector
+}
+
+// NewCostCollector creates a new cost collector
+func NewCostCollector() *CostCollector {
+	return &CostCollector{
+		BaseCollector: NewBaseCollector(ContentCost, 5*time.Second, true),
+	}
+}
+
+// Collect returns the cost segment, or "" when the reported cost is zero.
+func (c *CostCollector) Collect(input interface{}, summary interface{}) (string, error) {
+	statusInput, ok := input.(*StatusLineInput)
+	if !ok {
+		return "", fmt.Errorf("invalid input type")
+	}
+	return costSegment(statusInput), nil
+}
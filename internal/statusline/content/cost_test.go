@@ -0,0 +1,109 @@
+package content
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatCost(t *testing.T) {
+	t.Cleanup(func() { SetCurrency("USD", 1) })
+
+	tests := []struct {
+		name string
+		code string
+		rate float64
+		usd  float64
+		want string
+	}{
+		{"default USD", "", 0, 1.5, "$1.50"},
+		{"EUR converts with rate", "EUR", 0.9, 10, "€9.00"},
+		{"lowercase code normalised", "gbp", 0.8, 10, "£8.00"},
+		{"JPY has no decimals", "JPY", 150.4, 1.02, "¥153"},
+		{"unknown code uses code prefix", "CHF", 0.88, 10, "CHF 8.80"},
+		{"non-positive rate means no conversion", "EUR", -1, 10, "€10.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			SetCurrency(tt.code, tt.rate)
+
+			// Act
+			got := formatCost(tt.usd)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFormatCostPrecise(t *testing.T) {
+	t.Cleanup(func() { SetCurrency("USD", 1) })
+
+	tests := []struct {
+		name string
+		code string
+		usd  float64
+		want string
+	}{
+		{"above one uses two decimals", "USD", 1.234, "$1.23"},
+		{"below one uses four decimals", "USD", 0.0123, "$0.0123"},
+		{"JPY stays whole", "JPY", 0.5, "¥0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			SetCurrency(tt.code, 1)
+
+			// Act
+			got := formatCostPrecise(tt.usd)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCostCollector_Collect(t *testing.T) {
+	collector := NewCostCollector()
+
+	tests := []struct {
+		name    string
+		cost    float64
+		added   int
+		removed int
+		want    string
+	}{
+		{"zero cost omitted", 0, 10, 2, ""},
+		{"cost only", 2.5, 0, 0, "💰 $2.50"},
+		{"small cost with lines", 0.0123, 1200, 108, "💰 $0.0123 +1200/-108 lines"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			input := &StatusLineInput{}
+			input.Cost.TotalCostUSD = tt.cost
+			input.Cost.TotalLinesAdded = tt.added
+			input.Cost.TotalLinesRemoved = tt.removed
+
+			// Act
+			got, err := collector.Collect(input, nil)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCostCollector_Collect_InvalidInput(t *testing.T) {
+	// Act
+	_, err := NewCostCollector().Collect("invalid", nil)
+
+	// Assert
+	require.Error(t, err)
+}
@@ -1,6 +1,7 @@
 package content
 
 import (
+	"fmt"
 	"io/fs"
 	"os"
 	"strings"
@@ -252,6 +253,59 @@ func TestGetMemoryFilesInfo_CacheHit(t *testing.T) {
 	assert.Equal(t, info1, info2)
 }
 
+// TestGetMemoryFilesInfoCached_PerCwd verifies two projects refreshed within
+// the TTL each get their own counts instead of sharing the first one's.
+func TestGetMemoryFilesInfoCached_PerCwd(t *testing.T) {
+	defer restoreFileSystem()
+	clearMemoryCache()
+	defaultFileSystem = &StubFileSystem{
+		HomeDir: "/home/test",
+		StatReturns: map[string]error{
+			"/a/.claude/rules": nil,
+			"/b/.claude/rules": nil,
+		},
+		ReadDirReturns: map[string][]fs.DirEntry{
+			"/a/.claude/rules": {stubDirEntry{name: "r.md"}},
+			"/b/.claude/rules": {stubDirEntry{name: "r1.md"}, stubDirEntry{name: "r2.md"}},
+		},
+		ReadFileReturns: map[string][]byte{},
+	}
+
+	a := getMemoryFilesInfoCached("/a")
+	b := getMemoryFilesInfoCached("/b")
+	assert.Equal(t, 1, a.RulesCount)
+	assert.Equal(t, 2, b.RulesCount)
+
+	// Both stay cached after the filesystem changes
+	defaultFileSystem = &StubFileSystem{HomeDir: "/home/test"}
+	assert.Equal(t, 1, getMemoryFilesInfoCached("/a").RulesCount)
+	assert.Equal(t, 2, getMemoryFilesInfoCached("/b").RulesCount)
+}
+
+// TestGetMemoryFilesInfoCached_EvictsLeastRecentlyUsed verifies the cache
+// stays bounded and evicts the entry that was used longest ago.
+func TestGetMemoryFilesInfoCached_EvictsLeastRecentlyUsed(t *testing.T) {
+	defer restoreFileSystem()
+	clearMemoryCache()
+	defaultFileSystem = &StubFileSystem{
+		HomeDir:        "/home/test",
+		StatReturns:    map[string]error{"/p0/.claude/rules": nil},
+		ReadDirReturns: map[string][]fs.DirEntry{"/p0/.claude/rules": {stubDirEntry{name: "r.md"}}},
+	}
+
+	getMemoryFilesInfoCached("/p0")
+	for i := 1; i < memoryFilesCacheMax; i++ {
+		getMemoryFilesInfoCached(fmt.Sprintf("/p%d", i))
+	}
+	getMemoryFilesInfoCached("/p0") // touch: /p1 is now the least recently used
+	getMemoryFilesInfoCached("/new")
+
+	assert.Len(t, memoryFilesCache, memoryFilesCacheMax)
+	assert.Contains(t, memoryFilesCache, "/p0")
+	assert.NotContains(t, memoryFilesCache, "/p1")
+	assert.Contains(t, memoryFilesCache, "/new")
+}
+
 func TestGetMemoryFilesInfo_HomeDirError(t *testing.T) {
 	defer restoreFileSystem()
 	clearMemoryCache()
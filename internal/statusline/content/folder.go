@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/young1lin/claude-token-monitor/internal/textutil"
 )
 
 // StatusLineInput represents the input from Claude Code
@@ -69,6 +71,13 @@ type StatusLineInput struct {
 	// FastMode is the high-throughput / low-latency hint. Off by default;
 	// when true, the mode-flags collector renders ⚡.
 	FastMode bool `json:"fast_mode"`
+
+	// OutputStyle is the active output style (e.g. "explanatory",
+	// "learning"). The output-style collector only surfaces non-default
+	// styles, so "default" or a missing object renders nothing.
+	OutputStyle struct {
+		Name string `json:"name"`
+	} `json:"output_style"`
 }
 
 // StdinRateLimitWindow is one CC-supplied usage window. ResetsAt is Unix
@@ -97,6 +106,16 @@ type TranscriptSummary struct {
 	TodoCompleted  int
 	SessionStart   time.Time
 	SessionEnd     time.Time
+	// AwaitingApproval names the tool blocked on a permission prompt
+	AwaitingApproval string
+	// UserTurns is the session's real user message count. Only filled in
+	// when format.turnLimit is set, since it needs a full transcript scan.
+	UserTurns int
+	// ContextTokens is the context occupied as of the newest assistant
+	// message, reset by a compaction. Used when stdin has no current_usage.
+	ContextTokens int
+	// Compactions counts the context compactions seen in the transcript
+	Compactions int
 }
 
 // AgentInfo represents agent information
@@ -140,10 +159,6 @@ func getProjectName(cwd string) string {
 	// Slice by rune, not byte: a project name like "我的中文项目-app" would
 	// otherwise get cut mid-UTF-8 and render as broken replacement glyphs.
 	// 32-rune cap matches TruncateBranch so the two cells stay visually
-	// balanced; both leave 29 runes + ".." in the truncated case.
-	runes := []rune(name)
-	if len(runes) > 32 {
-		return string(runes[:29]) + ".."
-	}
-	return name
+	// balanced; both leave 29 runes + the ellipsis in the truncated case.
+	return textutil.Truncate(name, 32)
 }
@@ -117,6 +117,21 @@ func TestGetProjectName(t *testing.T) {
 			cwd:      "C:\\Users\\User\\minimal-mcp\\",
 			expected: "minimal-mcp",
 		},
+		{
+			name:     "WSL mount path",
+			cwd:      "/mnt/c/Users/User/minimal-mcp",
+			expected: "minimal-mcp",
+		},
+		{
+			name:     "Mixed separators from a WSL worktree",
+			cwd:      "/mnt/c/Users/User\\worktrees\\feature-x",
+			expected: "feature-x",
+		},
+		{
+			name:     "UNC path",
+			cwd:      "\\\\server\\share\\minimal-mcp",
+			expected: "minimal-mcp",
+		},
 		// Exactly 32 characters (boundary test — no truncation)
 		{
 			name:     "Exactly 32 characters",
@@ -2,25 +2,59 @@ package content
 
 import (
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/young1lin/claude-token-monitor/internal/textutil"
 )
 
 // Git caches
 var (
-	// Combined cache for parallel git operations (replaces individual caches)
+	// Combined cache for parallel git operations, keyed by working directory
+	// so a process that renders for several projects never serves one repo's
+	// branch for another.
 	gitCombinedCache struct {
-		branch     string
-		status     string
-		remote     string
-		lastUpdate time.Time
-		mu         sync.RWMutex
+		entries map[string]*gitCacheEntry
+		mu      sync.RWMutex
 	}
 	gitCombinedCacheTTL = 5 * time.Second
 )
 
+// gitCacheEntry is one directory's combined git data
+type gitCacheEntry struct {
+	branch     string
+	status     string
+	remote     string
+	lastUpdate time.Time
+}
+
+// gitCleanMarker is shown in place of an empty status when git.showClean is on
+const gitCleanMarker = "✓ clean"
+
+// showGitClean controls whether a clean working tree renders gitCleanMarker
+// instead of nothing. Off by default; set via SetShowGitClean from main.
+var (
+	showGitClean   bool
+	showGitCleanMu sync.RWMutex
+)
+
+// SetShowGitClean enables or disables the clean-tree marker. Thread-safe.
+func SetShowGitClean(enabled bool) {
+	showGitCleanMu.Lock()
+	defer showGitCleanMu.Unlock()
+	showGitClean = enabled
+}
+
+// getShowGitClean returns whether the clean-tree marker is enabled.
+func getShowGitClean() bool {
+	showGitCleanMu.RLock()
+	defer showGitCleanMu.RUnlock()
+	return showGitClean
+}
+
 // GitStatusData holds git status information
 type GitStatusData struct {
 	Added        int
@@ -63,13 +97,19 @@ func NewGitStatusCollector() *GitStatusCollector {
 	}
 }
 
-// Collect returns the git file status
+// Collect returns the git file status. A clean tree yields "" unless
+// git.showClean is enabled, in which case it yields "✓ clean" — but only
+// inside a repository, so non-git folders stay blank.
 func (c *GitStatusCollector) Collect(input interface{}, summary interface{}) (string, error) {
 	statusInput, ok := input.(*StatusLineInput)
 	if !ok {
 		return "", fmt.Errorf("invalid input type")
 	}
-	return getGitStatusCached(statusInput.Cwd), nil
+	status := getGitStatusCached(statusInput.Cwd)
+	if status == "" && getShowGitClean() && getGitBranchCached(statusInput.Cwd) != "" {
+		return gitCleanMarker, nil
+	}
+	return colorizeGitStatus(status), nil
 }
 
 // GitRemoteCollector collects git remote sync status
@@ -101,10 +141,8 @@ func getGitDataParallel(cwd string) (branch, status, remote string) {
 
 	// Check combined cache first
 	gitCombinedCache.mu.RLock()
-	if gitCombinedCache.branch != "" && now.Sub(gitCombinedCache.lastUpdate) < gitCombinedCacheTTL {
-		branch = gitCombinedCache.branch
-		status = gitCombinedCache.status
-		remote = gitCombinedCache.remote
+	if e := gitCombinedCache.entries[cwd]; e != nil && e.branch != "" && now.Sub(e.lastUpdate) < gitCombinedCacheTTL {
+		branch, status, remote = e.branch, e.status, e.remote
 		gitCombinedCache.mu.RUnlock()
 		return
 	}
@@ -140,12 +178,23 @@ func getGitDataParallel(cwd string) (branch, status, remote string) {
 
 	wg.Wait()
 
-	// Update combined cache
+	// Update combined cache, evicting directories not refreshed within 2× TTL
+	// so a long-lived process doesn't accumulate every repo it has visited.
 	gitCombinedCache.mu.Lock()
-	gitCombinedCache.branch = branch
-	gitCombinedCache.status = status
-	gitCombinedCache.remote = remote
-	gitCombinedCache.lastUpdate = now
+	if gitCombinedCache.entries == nil {
+		gitCombinedCache.entries = make(map[string]*gitCacheEntry)
+	}
+	for dir, e := range gitCombinedCache.entries {
+		if now.Sub(e.lastUpdate) > 2*gitCombinedCacheTTL {
+			delete(gitCombinedCache.entries, dir)
+		}
+	}
+	gitCombinedCache.entries[cwd] = &gitCacheEntry{
+		branch:     branch,
+		status:     status,
+		remote:     remote,
+		lastUpdate: now,
+	}
 	gitCombinedCache.mu.Unlock()
 
 	return
@@ -184,15 +233,44 @@ func formatGitStatus(added, deleted, modified int) string {
 	return strings.Join(statusParts, " ")
 }
 
+// Diff-stat colours for the git status components, matching common git UIs.
+const (
+	gitAddedColor    = "\x1b[32m" // green
+	gitModifiedColor = "\x1b[33m" // yellow
+	gitDeletedColor  = "\x1b[31m" // red
+)
+
+// colorizeGitStatus wraps each "+N" / "~N" / "-N" component of a
+// formatGitStatus result in its own colour. The cached status stays plain so
+// the colour decision is made at render time; NO_COLOR (any value) leaves it
+// uncoloured.
+func colorizeGitStatus(status string) string {
+	if status == "" || os.Getenv("NO_COLOR") != "" {
+		return status
+	}
+	parts := strings.Fields(status)
+	for i, part := range parts {
+		var color string
+		switch part[0] {
+		case '+':
+			color = gitAddedColor
+		case '~':
+			color = gitModifiedColor
+		case '-':
+			color = gitDeletedColor
+		default:
+			continue
+		}
+		parts[i] = color + part + "\x1b[0m"
+	}
+	return strings.Join(parts, " ")
+}
+
 // TruncateBranch limits branch name display length to 32 runes. Kept aligned
 // with getProjectName in folder.go so the two cells share the same visual
 // budget. Uses rune slicing for proper Unicode handling.
 func TruncateBranch(branch string) string {
-	runes := []rune(branch)
-	if len(runes) > 32 {
-		return string(runes[:29]) + ".."
-	}
-	return branch
+	return textutil.Truncate(branch, 32)
 }
 
 // getGitBranch reads the current git branch using defaultCommandRunner.
@@ -33,10 +33,7 @@ func (s *StubCommandRunner) Run(dir, name string, args ...string) ([]byte, error
 // resetGitCache clears the combined git cache.
 func resetGitCache() {
 	gitCombinedCache.mu.Lock()
-	gitCombinedCache.branch = ""
-	gitCombinedCache.status = ""
-	gitCombinedCache.remote = ""
-	gitCombinedCache.lastUpdate = time.Time{}
+	gitCombinedCache.entries = nil
 	gitCombinedCache.mu.Unlock()
 }
 
@@ -774,8 +771,8 @@ func TestGitStatusCollector(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if result != "+1" {
-		t.Errorf("expected %q, got %q", "+1", result)
+	if want := "\x1b[32m+1\x1b[0m"; result != want {
+		t.Errorf("expected %q, got %q", want, result)
 	}
 
 	// Invalid input type
@@ -785,6 +782,97 @@ func TestGitStatusCollector(t *testing.T) {
 	}
 }
 
+func TestGitStatusCollector_ShowClean(t *testing.T) {
+	defer restoreDefaultRunner()
+	t.Cleanup(func() { SetShowGitClean(false) })
+
+	tests := []struct {
+		name    string
+		enabled bool
+		outputs map[string][]byte
+		want    string
+	}{
+		{
+			name:    "clean repo with showClean",
+			enabled: true,
+			outputs: map[string][]byte{
+				"git symbolic-ref --short HEAD":                []byte("main\n"),
+				"git status --porcelain --untracked-files=all": []byte(""),
+			},
+			want: "✓ clean",
+		},
+		{
+			name:    "clean repo without showClean",
+			enabled: false,
+			outputs: map[string][]byte{
+				"git symbolic-ref --short HEAD":                []byte("main\n"),
+				"git status --porcelain --untracked-files=all": []byte(""),
+			},
+			want: "",
+		},
+		{
+			name:    "dirty repo ignores showClean",
+			enabled: true,
+			outputs: map[string][]byte{
+				"git symbolic-ref --short HEAD":                []byte("main\n"),
+				"git status --porcelain --untracked-files=all": []byte("?? file.txt\n"),
+			},
+			want: "\x1b[32m+1\x1b[0m",
+		},
+		{
+			name:    "not a repo stays blank",
+			enabled: true,
+			outputs: map[string][]byte{},
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			resetGitCache()
+			defaultCommandRunner = &StubCommandRunner{Outputs: tt.outputs}
+			SetShowGitClean(tt.enabled)
+
+			// Act
+			got, err := NewGitStatusCollector().Collect(&StatusLineInput{Cwd: "/project"}, nil)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestColorizeGitStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  string
+		noColor bool
+		want    string
+	}{
+		{"empty", "", false, ""},
+		{"all components", "+5 ~3 -2", false, "\x1b[32m+5\x1b[0m \x1b[33m~3\x1b[0m \x1b[31m-2\x1b[0m"},
+		{"modified only", "~1", false, "\x1b[33m~1\x1b[0m"},
+		{"NO_COLOR leaves plain text", "+5 ~3 -2", true, "+5 ~3 -2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			if tt.noColor {
+				t.Setenv("NO_COLOR", "1")
+			}
+
+			// Act
+			got := colorizeGitStatus(tt.status)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestGitRemoteCollector(t *testing.T) {
 	defer restoreDefaultRunner()
 	resetGitCache()
@@ -865,6 +953,68 @@ func TestGetGitDataParallel_CacheHit(t *testing.T) {
 	}
 }
 
+func TestGetGitDataParallel_KeyedByDirectory(t *testing.T) {
+	defer restoreDefaultRunner()
+	resetGitCache()
+
+	// Prime /repo-a
+	defaultCommandRunner = &StubCommandRunner{
+		Outputs: map[string][]byte{
+			"git symbolic-ref --short HEAD": []byte("main\n"),
+		},
+	}
+	branchA, _, _ := getGitDataParallel("/repo-a")
+
+	// A different directory must not be served /repo-a's cached branch
+	defaultCommandRunner = &StubCommandRunner{
+		Outputs: map[string][]byte{
+			"git symbolic-ref --short HEAD": []byte("feature\n"),
+		},
+	}
+	branchB, _, _ := getGitDataParallel("/repo-b")
+	branchA2, _, _ := getGitDataParallel("/repo-a")
+
+	if branchA != "main" {
+		t.Errorf("/repo-a: expected %q, got %q", "main", branchA)
+	}
+	if branchB != "feature" {
+		t.Errorf("/repo-b: expected %q, got %q", "feature", branchB)
+	}
+	if branchA2 != "main" {
+		t.Errorf("/repo-a second read: expected cached %q, got %q", "main", branchA2)
+	}
+}
+
+func TestGetGitDataParallel_EvictsStaleEntries(t *testing.T) {
+	defer restoreDefaultRunner()
+	resetGitCache()
+	gitCombinedCache.mu.Lock()
+	gitCombinedCache.entries = map[string]*gitCacheEntry{
+		"/old":    {branch: "main", lastUpdate: time.Now().Add(-3 * gitCombinedCacheTTL)},
+		"/recent": {branch: "main", lastUpdate: time.Now().Add(-gitCombinedCacheTTL)},
+	}
+	gitCombinedCache.mu.Unlock()
+	defaultCommandRunner = &StubCommandRunner{
+		Outputs: map[string][]byte{
+			"git symbolic-ref --short HEAD": []byte("main\n"),
+		},
+	}
+
+	getGitDataParallel("/project")
+
+	gitCombinedCache.mu.RLock()
+	defer gitCombinedCache.mu.RUnlock()
+	if _, ok := gitCombinedCache.entries["/old"]; ok {
+		t.Error("entry older than 2x TTL should have been evicted")
+	}
+	if _, ok := gitCombinedCache.entries["/recent"]; !ok {
+		t.Error("entry within 2x TTL should be kept")
+	}
+	if _, ok := gitCombinedCache.entries["/project"]; !ok {
+		t.Error("fetched directory should be cached")
+	}
+}
+
 func TestGetGitDataParallel_Concurrent(t *testing.T) {
 	defer restoreDefaultRunner()
 	resetGitCache()
@@ -43,6 +43,42 @@ func (m *Manager) RegisterAll(collectors ...ContentCollector) {
 	}
 }
 
+// Unregister removes the collector for a content type, along with any cached
+// value, so it is never invoked. Unknown types are ignored.
+func (m *Manager) Unregister(contentType ContentType) {
+	delete(m.collectors, contentType)
+	m.ClearTypeCache(contentType)
+}
+
+// Replace installs collector in place of whatever collects contentType, so
+// composers and layout cells that consume contentType receive its output
+// instead. The previous collector never runs. Used for content.use overrides.
+func (m *Manager) Replace(contentType ContentType, collector ContentCollector) {
+	m.collectors[contentType] = &replacementCollector{ContentCollector: collector, contentType: contentType}
+	m.ClearTypeCache(contentType)
+}
+
+// replacementCollector reports the content type it stands in for.
+type replacementCollector struct {
+	ContentCollector
+	contentType ContentType
+}
+
+// Type returns the replaced content type rather than the wrapped one's own.
+func (r *replacementCollector) Type() ContentType {
+	return r.contentType
+}
+
+// Types returns the content types of all registered collectors, in no
+// particular order.
+func (m *Manager) Types() []ContentType {
+	types := make([]ContentType, 0, len(m.collectors))
+	for ct := range m.collectors {
+		types = append(types, ct)
+	}
+	return types
+}
+
 // RegisterComposer registers a composer
 func (m *Manager) RegisterComposer(composer Composer) {
 	m.composers.Register(composer)
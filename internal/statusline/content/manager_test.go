@@ -104,6 +104,47 @@ func TestManager_RegisterAll(t *testing.T) {
 	assert.Equal(t, c3, m.collectors[ContentAgent])
 }
 
+func TestManager_Unregister(t *testing.T) {
+	// Arrange
+	m := NewManager()
+	c1 := newStubCollector(ContentModel, 5*time.Second, false)
+	c2 := newStubCollector(ContentQuota, 5*time.Second, true)
+	m.RegisterAll(c1, c2)
+	_, err := m.Get(ContentQuota, nil, nil)
+	require.NoError(t, err)
+
+	// Act
+	m.Unregister(ContentQuota)
+	m.Unregister(ContentAgent) // not registered: no-op
+	result := m.GetAll(nil, nil)
+
+	// Assert
+	assert.ElementsMatch(t, []ContentType{ContentModel}, m.Types())
+	assert.NotContains(t, result, ContentQuota)
+	assert.NotContains(t, m.cache, ContentQuota)
+	assert.Equal(t, 1, c2.getCallCount(), "unregistered collector must not run again")
+}
+
+func TestManager_Replace(t *testing.T) {
+	// Arrange
+	m := NewManager()
+	original := newStubCollector(ContentQuota, 5*time.Second, true)
+	replacement := newStubCollector(ContentQuotaDual, 5*time.Second, true)
+	m.Register(original)
+	_, err := m.Get(ContentQuota, nil, nil)
+	require.NoError(t, err)
+
+	// Act
+	m.Replace(ContentQuota, replacement)
+	result := m.GetAll(nil, nil)
+
+	// Assert
+	assert.ElementsMatch(t, []ContentType{ContentQuota}, m.Types())
+	assert.Equal(t, "stub-quota-dual", result[ContentQuota], "replacement output lands under the replaced type")
+	assert.Equal(t, ContentQuota, m.collectors[ContentQuota].Type())
+	assert.Equal(t, 1, original.getCallCount(), "replaced collector must not run again")
+}
+
 func TestManager_Get(t *testing.T) {
 	t.Run("unregistered type returns error", func(t *testing.T) {
 		// Arrange
@@ -11,17 +11,28 @@ import (
 	"github.com/young1lin/claude-token-monitor/internal/claudedir"
 )
 
-// Memory files cache
+// Memory files cache, keyed by cwd so two projects refreshed within the TTL
+// don't share counts. Bounded to memoryFilesCacheMax entries; the least
+// recently used one is evicted first.
 var (
-	memoryFilesCache     *MemoryFilesInfo
-	memoryFilesCacheMu   sync.RWMutex
-	memoryFilesCacheTime time.Time
-	memoryFilesCacheTTL  = 60 * time.Second
+	memoryFilesCache    = make(map[string]*memoryFilesCacheEntry)
+	memoryFilesCacheMu  sync.Mutex
+	memoryFilesCacheSeq uint64 // recency counter, bumped on every hit or store
+	memoryFilesCacheTTL = 60 * time.Second
+	memoryFilesCacheMax = 16
 )
 
+// memoryFilesCacheEntry is one cwd's cached info.
+type memoryFilesCacheEntry struct {
+	info     MemoryFilesInfo
+	fetched  time.Time
+	lastUsed uint64
+}
+
 // MemoryFilesInfo stores memory files statistics
 type MemoryFilesInfo struct {
 	CLAUDEMdCount int
+	ImportCount   int // files pulled in via @path imports, transitively
 	RulesCount    int
 	MCPCount      int
 	HooksCount    int
@@ -49,33 +60,52 @@ func (c *MemoryFilesCollector) Collect(input interface{}, summary interface{}) (
 	return formatMemoryFilesDisplay(info), nil
 }
 
-// getMemoryFilesInfoCached returns cached memory files info
+// getMemoryFilesInfoCached returns the memory files info for cwd, cached per
+// cwd for memoryFilesCacheTTL.
 func getMemoryFilesInfoCached(cwd string) MemoryFilesInfo {
 	now := time.Now()
 
-	memoryFilesCacheMu.RLock()
-	if memoryFilesCache != nil && now.Sub(memoryFilesCacheTime) < memoryFilesCacheTTL {
-		cached := *memoryFilesCache
-		memoryFilesCacheMu.RUnlock()
-		return cached
+	memoryFilesCacheMu.Lock()
+	if e, ok := memoryFilesCache[cwd]; ok && now.Sub(e.fetched) < memoryFilesCacheTTL {
+		memoryFilesCacheSeq++
+		e.lastUsed = memoryFilesCacheSeq
+		info := e.info
+		memoryFilesCacheMu.Unlock()
+		return info
 	}
-	memoryFilesCacheMu.RUnlock()
+	memoryFilesCacheMu.Unlock()
 
 	info := getMemoryFilesInfo(cwd)
 
 	memoryFilesCacheMu.Lock()
-	memoryFilesCache = &info
-	memoryFilesCacheTime = now
-	memoryFilesCacheMu.Unlock()
-
+	defer memoryFilesCacheMu.Unlock()
+	if _, ok := memoryFilesCache[cwd]; !ok && len(memoryFilesCache) >= memoryFilesCacheMax {
+		evictLRUMemoryFilesEntry()
+	}
+	memoryFilesCacheSeq++
+	memoryFilesCache[cwd] = &memoryFilesCacheEntry{info: info, fetched: now, lastUsed: memoryFilesCacheSeq}
 	return info
 }
 
+// evictLRUMemoryFilesEntry drops the least recently used cache entry. The
+// caller must hold memoryFilesCacheMu.
+func evictLRUMemoryFilesEntry() {
+	var oldest string
+	var oldestSeq uint64
+	first := true
+	for cwd, e := range memoryFilesCache {
+		if first || e.lastUsed < oldestSeq {
+			oldest, oldestSeq, first = cwd, e.lastUsed, false
+		}
+	}
+	delete(memoryFilesCache, oldest)
+}
+
 // clearMemoryCache resets the memory cache (for tests).
 func clearMemoryCache() {
 	memoryFilesCacheMu.Lock()
-	memoryFilesCache = nil
-	memoryFilesCacheTime = time.Time{}
+	memoryFilesCache = make(map[string]*memoryFilesCacheEntry)
+	memoryFilesCacheSeq = 0
 	memoryFilesCacheMu.Unlock()
 }
 
@@ -83,17 +113,18 @@ func clearMemoryCache() {
 func getMemoryFilesInfo(cwd string) MemoryFilesInfo {
 	info := MemoryFilesInfo{}
 	fs := defaultFileSystem
+	var memoryFiles []string
 
 	// 1. Check Enterprise policy (Windows)
 	if currentOS == "windows" {
 		enterprisePath := filepath.Join("C:", "Program Files", "ClaudeCode", "CLAUDE.md")
 		if _, err := fs.Stat(enterprisePath); err == nil {
-			info.CLAUDEMdCount++
+			memoryFiles = append(memoryFiles, enterprisePath)
 		}
 	}
 
 	// 2 & 5. Recursive search for CLAUDE.md and CLAUDE.local.md
-	info.CLAUDEMdCount += countClaudeMdUpward(cwd)
+	memoryFiles = append(memoryFiles, findClaudeMdUpward(cwd)...)
 
 	// 3. Scan .claude/rules/ directories
 	info.RulesCount += countRulesUpward(cwd)
@@ -101,15 +132,22 @@ func getMemoryFilesInfo(cwd string) MemoryFilesInfo {
 	// 4. Check User memory under the active config dir (honors
 	// $CLAUDE_CONFIG_DIR so multi-account setups don't read the wrong tree).
 	if claudeDir, err := claudedir.Resolve(fs.UserHomeDir); err == nil {
-		if _, err := fs.Stat(filepath.Join(claudeDir, "CLAUDE.md")); err == nil {
-			info.CLAUDEMdCount++
+		userPath := filepath.Join(claudeDir, "CLAUDE.md")
+		if _, err := fs.Stat(userPath); err == nil {
+			memoryFiles = append(memoryFiles, userPath)
 		}
 		info.RulesCount += countRulesRecursive(filepath.Join(claudeDir, "rules"))
 	}
 
+	info.CLAUDEMdCount = len(memoryFiles)
+	info.ImportCount = countMemoryImports(memoryFiles)
+
 	// Get MCP count
 	info.MCPCount = getMCPCount(cwd)
 
+	// Get hooks count
+	info.HooksCount = getHooksCount(cwd)
+
 	return info
 }
 
@@ -141,33 +179,26 @@ func countRulesUpward(cwd string) int {
 
 // countClaudeMdUpward searches upward for CLAUDE.md files
 func countClaudeMdUpward(cwd string) int {
-	count := 0
+	return len(findClaudeMdUpward(cwd))
+}
+
+// findClaudeMdUpward returns the CLAUDE.md, .claude/CLAUDE.md and
+// CLAUDE.local.md files found walking upward from cwd, nearest first.
+func findClaudeMdUpward(cwd string) []string {
+	var found []string
 	seen := make(map[string]bool)
 
 	cwd = filepath.Clean(cwd)
 
 	for i := 0; i < 10; i++ {
-		rootPath := filepath.Join(cwd, "CLAUDE.md")
-		if _, err := defaultFileSystem.Stat(rootPath); err == nil {
-			if !seen[rootPath] {
-				count++
-				seen[rootPath] = true
-			}
-		}
-
-		claudePath := filepath.Join(cwd, ".claude", "CLAUDE.md")
-		if _, err := defaultFileSystem.Stat(claudePath); err == nil {
-			if !seen[claudePath] {
-				count++
-				seen[claudePath] = true
-			}
-		}
-
-		localPath := filepath.Join(cwd, "CLAUDE.local.md")
-		if _, err := defaultFileSystem.Stat(localPath); err == nil {
-			if !seen[localPath] {
-				count++
-				seen[localPath] = true
+		for _, path := range []string{
+			filepath.Join(cwd, "CLAUDE.md"),
+			filepath.Join(cwd, ".claude", "CLAUDE.md"),
+			filepath.Join(cwd, "CLAUDE.local.md"),
+		} {
+			if _, err := defaultFileSystem.Stat(path); err == nil && !seen[path] {
+				found = append(found, path)
+				seen[path] = true
 			}
 		}
 
@@ -178,9 +209,98 @@ func countClaudeMdUpward(cwd string) int {
 		cwd = parent
 	}
 
+	return found
+}
+
+// maxImportDepth mirrors Claude Code's limit on recursive @path imports.
+const maxImportDepth = 5
+
+// countMemoryImports counts the distinct existing files reachable through
+// @path imports from the given memory files, following imports up to
+// maxImportDepth hops. Files already in memoryFiles are not counted again.
+func countMemoryImports(memoryFiles []string) int {
+	seen := make(map[string]bool, len(memoryFiles))
+	for _, path := range memoryFiles {
+		seen[filepath.Clean(path)] = true
+	}
+
+	count := 0
+	var walk func(path string, depth int)
+	walk = func(path string, depth int) {
+		if depth >= maxImportDepth {
+			return
+		}
+		data, err := defaultFileSystem.ReadFile(path)
+		if err != nil {
+			return
+		}
+		for _, ref := range parseMemoryImports(string(data)) {
+			target := resolveMemoryImport(ref, filepath.Dir(path))
+			if target == "" || seen[target] {
+				continue
+			}
+			if fi, err := defaultFileSystem.Stat(target); err != nil || fi.IsDir() {
+				continue
+			}
+			seen[target] = true
+			count++
+			walk(target, depth+1)
+		}
+	}
+
+	for _, path := range memoryFiles {
+		walk(path, 0)
+	}
 	return count
 }
 
+// parseMemoryImports returns the @path references in a memory file. Like
+// Claude Code, imports inside fenced code blocks and inline code spans are
+// ignored, and an @ must start a word so e-mail addresses don't match.
+func parseMemoryImports(text string) []string {
+	var refs []string
+	inFence := false
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		for i, field := range strings.Split(line, "`") {
+			if i%2 == 1 {
+				continue // inside an inline code span
+			}
+			for _, word := range strings.Fields(field) {
+				// Trailing punctuation belongs to the sentence, not the path.
+				word = strings.TrimRight(word, ".,;:!?)")
+				if len(word) > 1 && word[0] == '@' {
+					refs = append(refs, word[1:])
+				}
+			}
+		}
+	}
+	return refs
+}
+
+// resolveMemoryImport resolves an @path reference relative to the importing
+// file's directory; "~/" paths resolve against the home directory.
+func resolveMemoryImport(ref, dir string) string {
+	switch {
+	case strings.HasPrefix(ref, "~/"):
+		home, err := defaultFileSystem.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		return filepath.Join(home, ref[2:])
+	case filepath.IsAbs(ref):
+		return filepath.Clean(ref)
+	default:
+		return filepath.Join(dir, ref)
+	}
+}
+
 // countRulesRecursive recursively counts .md files in a rules directory
 func countRulesRecursive(rulesDir string) int {
 	count := 0
@@ -225,7 +345,21 @@ func getMCPCount(cwd string) int {
 		}
 	}
 
-	// Method 2: Check active config dir's settings.json for mcpServers (honors
+	// Method 2: Check the project-root .mcp.json, where newer Claude Code
+	// versions keep project-scoped servers under a top-level "mcpServers"
+	// object. Preferred over the global settings below.
+	if count == 0 {
+		if data, err := fs.ReadFile(filepath.Join(cwd, ".mcp.json")); err == nil {
+			var project struct {
+				MCPServers map[string]json.RawMessage `json:"mcpServers"`
+			}
+			if err := json.Unmarshal(data, &project); err == nil {
+				count = len(project.MCPServers)
+			}
+		}
+	}
+
+	// Method 3: Check active config dir's settings.json for mcpServers (honors
 	// $CLAUDE_CONFIG_DIR — multi-account users have a settings.json per
 	// account and need MCP counts from the one they're actually using).
 	if count == 0 {
@@ -245,20 +379,157 @@ func getMCPCount(cwd string) int {
 	return count
 }
 
+// hookScriptExts are the script types counted in .claude/hooks/.
+var hookScriptExts = map[string]bool{".sh": true, ".py": true, ".js": true}
+
+// getHooksCount counts the distinct hooks configured in the active config
+// dir's settings.json and the project's .claude/settings.json, plus hook
+// scripts in the matching .claude/hooks/ directories that no configured
+// hook's command refers to. A hook registered identically in both files is
+// counted once, and so is a directory reached both ways (cwd is $HOME).
+func getHooksCount(cwd string) int {
+	fs := defaultFileSystem
+	var dirs []string
+	seenDirs := make(map[string]bool)
+	addDir := func(dir string) {
+		dir = filepath.Clean(dir)
+		if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+			dir = resolved
+		}
+		if !seenDirs[dir] {
+			seenDirs[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	addDir(filepath.Join(cwd, ".claude"))
+	if claudeDir, err := claudedir.Resolve(fs.UserHomeDir); err == nil {
+		addDir(claudeDir)
+	}
+
+	seen := make(map[string]bool)
+	scripts := make(map[string]bool) // base names of the paths hook commands mention
+	for _, dir := range dirs {
+		data, err := fs.ReadFile(filepath.Join(dir, "settings.json"))
+		if err != nil {
+			continue
+		}
+		var settings struct {
+			Hooks interface{} `json:"hooks"`
+		}
+		if err := json.Unmarshal(data, &settings); err != nil {
+			continue
+		}
+		for _, hook := range configuredHooks(settings.Hooks) {
+			seen[hook.key] = true
+			for _, field := range strings.Fields(hook.command) {
+				scripts[filepath.Base(strings.Trim(field, `"'`))] = true
+			}
+		}
+	}
+
+	// A script already wired up in settings is the same hook, not another.
+	count := len(seen)
+	for _, dir := range dirs {
+		for _, name := range listHookScripts(filepath.Join(dir, "hooks")) {
+			if !scripts[name] {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// listHookScripts returns the names of the .sh, .py and .js files directly
+// inside hooksDir, skipping hidden files.
+func listHookScripts(hooksDir string) []string {
+	entries, err := defaultFileSystem.ReadDir(hooksDir)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") || !hookScriptExts[filepath.Ext(name)] {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// configuredHook is one hook from settings.json: an identity key combining
+// event, matcher and the hook's canonical JSON, and the command it runs.
+type configuredHook struct {
+	key     string
+	command string
+}
+
+// configuredHooks returns one entry per configured hook. The usual shape is
+// an object of event name → [{matcher, hooks: [{type, command}]}]; a flat
+// array of hook entries (with or without the matcher wrapper) is accepted
+// too.
+func configuredHooks(hooks interface{}) []configuredHook {
+	var out []configuredHook
+	add := func(event string, entries interface{}) {
+		list, ok := entries.([]interface{})
+		if !ok {
+			list = []interface{}{entries}
+		}
+		for _, entry := range list {
+			m, ok := entry.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			inner, ok := m["hooks"].([]interface{})
+			if !ok {
+				// Flat entry: the object is the hook itself.
+				if data, err := json.Marshal(m); err == nil {
+					command, _ := m["command"].(string)
+					out = append(out, configuredHook{key: event + "|" + string(data), command: command})
+				}
+				continue
+			}
+			matcher, _ := m["matcher"].(string)
+			for _, hook := range inner {
+				if data, err := json.Marshal(hook); err == nil {
+					var command string
+					if hm, ok := hook.(map[string]interface{}); ok {
+						command, _ = hm["command"].(string)
+					}
+					out = append(out, configuredHook{key: event + "|" + matcher + "|" + string(data), command: command})
+				}
+			}
+		}
+	}
+
+	switch h := hooks.(type) {
+	case map[string]interface{}:
+		for event, entries := range h {
+			add(event, entries)
+		}
+	case []interface{}:
+		add("", h)
+	}
+	return out
+}
+
 // formatMemoryFilesDisplay formats memory files display text
 func formatMemoryFilesDisplay(info MemoryFilesInfo) string {
-	if info.CLAUDEMdCount == 0 && info.RulesCount == 0 && info.MCPCount == 0 {
+	if info.CLAUDEMdCount == 0 && info.RulesCount == 0 && info.MCPCount == 0 && info.HooksCount == 0 {
 		return ""
 	}
 
 	parts := []string{}
 
 	if info.CLAUDEMdCount > 0 {
-		if info.CLAUDEMdCount == 1 {
-			parts = append(parts, "CLAUDE.md")
-		} else {
-			parts = append(parts, fmt.Sprintf("%d CLAUDE.md", info.CLAUDEMdCount))
+		part := "CLAUDE.md"
+		if info.CLAUDEMdCount > 1 {
+			part = fmt.Sprintf("%d CLAUDE.md", info.CLAUDEMdCount)
 		}
+		if info.ImportCount > 0 {
+			part += fmt.Sprintf(" (+%d imports)", info.ImportCount)
+		}
+		parts = append(parts, part)
 	}
 
 	if info.RulesCount > 0 {
@@ -269,5 +540,9 @@ func formatMemoryFilesDisplay(info MemoryFilesInfo) string {
 		parts = append(parts, fmt.Sprintf("%d MCPs", info.MCPCount))
 	}
 
+	if info.HooksCount > 0 {
+		parts = append(parts, fmt.Sprintf("%d hooks", info.HooksCount))
+	}
+
 	return "📦 " + strings.Join(parts, " + ")
 }
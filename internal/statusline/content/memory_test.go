@@ -2,6 +2,7 @@ package content
 
 import (
 	"encoding/json"
+	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -46,6 +47,15 @@ func TestFormatMemoryFilesDisplay(t *testing.T) {
 			},
 			want: "📦 2 rules + 3 MCPs",
 		},
+		{
+			name: "hooks after MCPs",
+			info: MemoryFilesInfo{
+				CLAUDEMdCount: 1,
+				MCPCount:      2,
+				HooksCount:    4,
+			},
+			want: "📦 CLAUDE.md + 2 MCPs + 4 hooks",
+		},
 	}
 
 	for _, tt := range tests {
@@ -397,6 +407,54 @@ func TestGetMCPCount(t *testing.T) {
 			},
 			want: 2,
 		},
+		{
+			name: "project .mcp.json fixture with three servers",
+			setup: func(t *testing.T) string {
+				dir := t.TempDir()
+				copyFixture(t, "project.mcp.json", filepath.Join(dir, ".mcp.json"))
+
+				t.Setenv("HOME", dir)
+				t.Setenv("USERPROFILE", dir)
+				return dir
+			},
+			want: 3,
+		},
+		{
+			name: "project .mcp.json takes priority over settings.json",
+			setup: func(t *testing.T) string {
+				dir := t.TempDir()
+				home := t.TempDir()
+				copyFixture(t, "project.mcp.json", filepath.Join(dir, ".mcp.json"))
+
+				// global settings.json with 1 server (should be ignored)
+				require.NoError(t, os.Mkdir(filepath.Join(home, ".claude"), 0755))
+				settings := `{"mcpServers": {"global-only": {}}}`
+				require.NoError(t, os.WriteFile(filepath.Join(home, ".claude", "settings.json"), []byte(settings), 0644))
+
+				t.Setenv("HOME", home)
+				t.Setenv("USERPROFILE", home)
+				t.Setenv("CLAUDE_CONFIG_DIR", "")
+				return dir
+			},
+			want: 3,
+		},
+		{
+			name: "malformed .mcp.json falls back to settings.json",
+			setup: func(t *testing.T) string {
+				dir := t.TempDir()
+				require.NoError(t, os.WriteFile(filepath.Join(dir, ".mcp.json"), []byte("{not json"), 0644))
+
+				require.NoError(t, os.Mkdir(filepath.Join(dir, ".claude"), 0755))
+				settings := `{"mcpServers": {"global-only": {}}}`
+				require.NoError(t, os.WriteFile(filepath.Join(dir, ".claude", "settings.json"), []byte(settings), 0644))
+
+				t.Setenv("HOME", dir)
+				t.Setenv("USERPROFILE", dir)
+				t.Setenv("CLAUDE_CONFIG_DIR", "")
+				return dir
+			},
+			want: 1,
+		},
 	}
 
 	for _, tt := range tests {
@@ -518,6 +576,67 @@ func TestFormatMemoryFilesDisplay_MultipleCLAUDEMd(t *testing.T) {
 	assert.Equal(t, "📦 3 CLAUDE.md", got)
 }
 
+func TestFormatMemoryFilesDisplay_WithImports(t *testing.T) {
+	got := formatMemoryFilesDisplay(MemoryFilesInfo{CLAUDEMdCount: 1, ImportCount: 3, RulesCount: 2})
+	assert.Equal(t, "📦 CLAUDE.md (+3 imports) + 2 rules", got)
+}
+
+func TestParseMemoryImports(t *testing.T) {
+	text := "See @docs/style.md and @~/.claude/personal.md.\n" +
+		"Mail me at dev@example.com, not `@inline/code.md`.\n" +
+		"```\n@fenced/example.md\n```\n" +
+		"- @README\n"
+
+	got := parseMemoryImports(text)
+
+	assert.Equal(t, []string{"docs/style.md", "~/.claude/personal.md", "README"}, got)
+}
+
+// TestCountMemoryImports_Transitive verifies imports are followed through
+// imported files, counted once each, and that missing targets, cycles and
+// the top-level memory files themselves are not counted.
+func TestCountMemoryImports_Transitive(t *testing.T) {
+	// Arrange
+	defer restoreFileSystem()
+	defaultFileSystem = &RealFileSystem{}
+	dir := t.TempDir()
+	write := func(name, body string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(body), 0644))
+		return path
+	}
+	root := write("CLAUDE.md", "@docs/a.md\n@docs/missing.md\n@CLAUDE.local.md\n")
+	local := write("CLAUDE.local.md", "@docs/a.md\n")
+	write("docs/a.md", "@b.md\n")
+	write("docs/b.md", "@a.md\n@../shared/c.md\n")
+	write("shared/c.md", "no imports here")
+
+	// Act
+	got := countMemoryImports([]string{root, local})
+
+	// Assert: a.md, b.md and c.md
+	assert.Equal(t, 3, got)
+}
+
+func TestCountMemoryImports_DepthLimit(t *testing.T) {
+	// Arrange: a chain of 7 imports; only maxImportDepth hops are followed
+	defer restoreFileSystem()
+	defaultFileSystem = &RealFileSystem{}
+	dir := t.TempDir()
+	for i := 0; i < 7; i++ {
+		body := fmt.Sprintf("@f%d.md\n", i+1)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, fmt.Sprintf("f%d.md", i)), []byte(body), 0644))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "f7.md"), nil, 0644))
+
+	// Act
+	got := countMemoryImports([]string{filepath.Join(dir, "f0.md")})
+
+	// Assert
+	assert.Equal(t, maxImportDepth, got)
+}
+
 // ---------------------------------------------------------------------------
 // CLAUDE_CONFIG_DIR honoring — regression guards for the multi-account bug
 // where global memory/rules/MCP counts came from ~/.claude instead of the
@@ -620,3 +739,153 @@ func TestGetMCPCount_GlobalSettingsHonorsConfigDir(t *testing.T) {
 	got := getMCPCount(cwd)
 	assert.Equal(t, 2, got, "must read $CLAUDE_CONFIG_DIR/settings.json, not <home>/.claude/settings.json")
 }
+
+func TestGetHooksCount(t *testing.T) {
+	const (
+		objectShaped = `{"hooks": {
+			"PreToolUse": [{"matcher": "Bash", "hooks": [
+				{"type": "command", "command": "guard.sh"},
+				{"type": "command", "command": "log.sh"}
+			]}],
+			"Stop": [{"hooks": [{"type": "command", "command": "notify.sh"}]}]
+		}}`
+		sameAsGlobal = `{"hooks": {
+			"PreToolUse": [{"matcher": "Bash", "hooks": [{"command": "guard.sh", "type": "command"}]}],
+			"PostToolUse": [{"matcher": "Edit", "hooks": [{"type": "command", "command": "fmt.sh"}]}]
+		}}`
+		arrayShaped = `{"hooks": [
+			{"event": "PreToolUse", "matcher": "Bash", "command": "guard.sh"},
+			{"event": "Stop", "command": "notify.sh"}
+		]}`
+		flatEvent = `{"hooks": {"Stop": [{"type": "command", "command": "notify.sh"}]}}`
+	)
+
+	tests := []struct {
+		name    string
+		global  string
+		project string
+		want    int
+	}{
+		{"no settings", "", "", 0},
+		{"object-shaped global", objectShaped, "", 3},
+		{"duplicate across files counted once", objectShaped, sameAsGlobal, 4},
+		{"array-shaped project", "", arrayShaped, 2},
+		{"event with flat hook list", flatEvent, "", 1},
+		{"invalid JSON ignored", "{not json", objectShaped, 3},
+		{"settings without hooks", `{"model": "opus"}`, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			t.Setenv("CLAUDE_CONFIG_DIR", "")
+			defer restoreFileSystem()
+			files := map[string][]byte{}
+			if tt.global != "" {
+				files["/home/test/.claude/settings.json"] = []byte(tt.global)
+			}
+			if tt.project != "" {
+				files["/project/.claude/settings.json"] = []byte(tt.project)
+			}
+			defaultFileSystem = &StubFileSystem{HomeDir: "/home/test", ReadFileReturns: files}
+
+			// Act
+			got := getHooksCount("/project")
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetHooksCount_HookScripts(t *testing.T) {
+	// Arrange: 3 scripts in .claude/hooks/, one of them already configured
+	t.Setenv("CLAUDE_CONFIG_DIR", "")
+	defer restoreFileSystem()
+	defaultFileSystem = &StubFileSystem{
+		HomeDir: "/home/test",
+		ReadDirReturns: map[string][]fs.DirEntry{
+			"/project/.claude/hooks": {
+				stubDirEntry{name: "format.sh"},
+				stubDirEntry{name: "lint.py"},
+				stubDirEntry{name: "notify.js"},
+				stubDirEntry{name: "README.md"},
+				stubDirEntry{name: ".draft.sh"},
+				stubDirEntry{name: "lib", isDir: true},
+			},
+		},
+		ReadFileReturns: map[string][]byte{},
+	}
+
+	// Act
+	scriptsOnly := getHooksCount("/project")
+	defaultFileSystem.(*StubFileSystem).ReadFileReturns["/project/.claude/settings.json"] = []byte(`{"hooks": {
+		"PostToolUse": [{"matcher": "Edit", "hooks": [{"type": "command", "command": "$CLAUDE_PROJECT_DIR/.claude/hooks/format.sh"}]}]
+	}}`)
+	withSettings := getHooksCount("/project")
+
+	// Assert
+	assert.Equal(t, 3, scriptsOnly)
+	assert.Equal(t, 3, withSettings, "format.sh is configured in settings.json and counts once")
+}
+
+// TestGetHooksCount_ScriptNameIsNotSubstring verifies a script is matched to
+// configured commands by file name, not by any command containing its name.
+func TestGetHooksCount_ScriptNameIsNotSubstring(t *testing.T) {
+	// Arrange
+	t.Setenv("CLAUDE_CONFIG_DIR", "")
+	defer restoreFileSystem()
+	defaultFileSystem = &StubFileSystem{
+		HomeDir: "/home/test",
+		ReadDirReturns: map[string][]fs.DirEntry{
+			"/project/.claude/hooks": {
+				stubDirEntry{name: "lint.sh"},
+				stubDirEntry{name: "a.sh"},
+				stubDirEntry{name: "fmt.py"},
+			},
+		},
+		ReadFileReturns: map[string][]byte{
+			"/project/.claude/settings.json": []byte(`{"hooks": {
+				"PostToolUse": [{"matcher": "Edit", "hooks": [
+					{"type": "command", "command": "bash .claude/hooks/prelint.sh --data.sh"},
+					{"type": "command", "command": "python3 \"$CLAUDE_PROJECT_DIR/.claude/hooks/fmt.py\""}
+				]}]
+			}}`),
+		},
+	}
+
+	// Act
+	got := getHooksCount("/project")
+
+	// Assert
+	assert.Equal(t, 4, got, "2 configured hooks + lint.sh and a.sh; fmt.py is configured")
+}
+
+// TestGetHooksCount_CwdIsHome verifies that when cwd is the config dir's
+// parent, the shared .claude directory is only read once.
+func TestGetHooksCount_CwdIsHome(t *testing.T) {
+	// Arrange
+	t.Setenv("CLAUDE_CONFIG_DIR", "")
+	defer restoreFileSystem()
+	defaultFileSystem = &StubFileSystem{
+		HomeDir: "/home/test",
+		ReadDirReturns: map[string][]fs.DirEntry{
+			"/home/test/.claude/hooks": {stubDirEntry{name: "notify.sh"}},
+		},
+		ReadFileReturns: map[string][]byte{},
+	}
+
+	// Act
+	got := getHooksCount("/home/test/")
+
+	// Assert
+	assert.Equal(t, 1, got)
+}
+
+// copyFixture copies testdata/name to dst.
+func copyFixture(t *testing.T, name, dst string) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(dst, data, 0644))
+}
@@ -86,3 +86,30 @@ func effortChip(level string) string {
 		return ""
 	}
 }
+
+// OutputStyleCollector reminds the user they have switched Claude Code to a
+// non-default output style, e.g. "🎨 explanatory". Empty for "default" or
+// when the host doesn't send output_style, so normal output is unchanged.
+type OutputStyleCollector struct {
+	*BaseCollector
+}
+
+// NewOutputStyleCollector creates a new output-style collector
+func NewOutputStyleCollector() *OutputStyleCollector {
+	return &OutputStyleCollector{
+		BaseCollector: NewBaseCollector(ContentOutputStyle, 1*time.Second, true),
+	}
+}
+
+// Collect returns "🎨 <style>" for non-default output styles
+func (c *OutputStyleCollector) Collect(input interface{}, _ interface{}) (string, error) {
+	statusInput, ok := input.(*StatusLineInput)
+	if !ok || statusInput == nil {
+		return "", fmt.Errorf("invalid input type")
+	}
+	name := strings.TrimSpace(statusInput.OutputStyle.Name)
+	if name == "" || strings.EqualFold(name, "default") {
+		return "", nil
+	}
+	return "🎨 " + name, nil
+}
@@ -1,6 +1,7 @@
 package content
 
 import (
+	"encoding/json"
 	"strings"
 	"testing"
 
@@ -148,3 +149,42 @@ func TestModeFlagsCollector_Properties(t *testing.T) {
 	assert.Equal(t, ContentModeFlags, c.Type())
 	assert.True(t, c.Optional(), "mode-flags must be optional so the cell hides when output is empty")
 }
+
+func TestOutputStyleCollector_Collect(t *testing.T) {
+	tests := []struct {
+		name  string
+		style string
+		want  string
+	}{
+		{"missing", "", ""},
+		{"default", "default", ""},
+		{"default any case", "Default", ""},
+		{"explanatory", "explanatory", "🎨 explanatory"},
+		{"learning", "learning", "🎨 learning"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var in StatusLineInput
+			in.OutputStyle.Name = tt.style
+
+			got, err := NewOutputStyleCollector().Collect(&in, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestOutputStyleCollector_Collect_FromJSON(t *testing.T) {
+	var in StatusLineInput
+	require.NoError(t, json.Unmarshal([]byte(`{"output_style":{"name":"explanatory"}}`), &in))
+
+	got, err := NewOutputStyleCollector().Collect(&in, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "🎨 explanatory", got)
+}
+
+func TestOutputStyleCollector_Collect_InvalidInput(t *testing.T) {
+	_, err := NewOutputStyleCollector().Collect("not a StatusLineInput", nil)
+	require.Error(t, err)
+}
@@ -3,9 +3,45 @@ package content
 import (
 	"fmt"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/young1lin/claude-token-monitor/internal/statusline/config"
+	"github.com/young1lin/claude-token-monitor/internal/termutil"
+)
+
+// Token bar width bounds, shared with format.barWidth validation. The width
+// comes from format.barWidth (see SetBarWidth) and can be overridden with
+// STATUSLINE_BAR_WIDTH, clamped to [minBarWidth, maxBarWidth].
+const (
+	defaultBarWidth = config.DefaultBarWidth
+	minBarWidth     = config.MinBarWidth
+	maxBarWidth     = config.MaxBarWidth
+)
+
+// barWidth is the configured token bar width; set via SetBarWidth from main.
+var (
+	barWidth   = defaultBarWidth
+	barWidthMu sync.RWMutex
 )
 
+// SetBarWidth sets the token bar width from format.barWidth. Callers pass an
+// already-clamped value (config.GetBarWidth). Thread-safe.
+func SetBarWidth(width int) {
+	barWidthMu.Lock()
+	defer barWidthMu.Unlock()
+	barWidth = width
+}
+
+// getBarWidth returns the token bar width: STATUSLINE_BAR_WIDTH when set,
+// otherwise the configured width.
+func getBarWidth() int {
+	barWidthMu.RLock()
+	configured := barWidth
+	barWidthMu.RUnlock()
+	return termutil.GetBarWidth(termutil.BarWidthEnvVar, configured, minBarWidth, maxBarWidth)
+}
+
 // ModelCollector collects the model display name
 type ModelCollector struct {
 	*BaseCollector
@@ -52,6 +88,36 @@ func NewTokenBarCollector() *TokenBarCollector {
 // regressions from a bloated context.
 const standardContextWindowSize = 200_000
 
+// ContextTokens returns the tokens currently occupying the context window and
+// the window's size. When the host doesn't report a size, it is looked up
+// from the model ID (config.GetContextWindow), which itself falls back to the
+// standard 200K window for unknown models. Shared by the bar, the info text
+// and JSON output so all three agree on the numbers.
+//
+// Cache writes (cache_creation_input_tokens) count too: those tokens sit in
+// the context just like cache reads, and leaving them out under-reported
+// cache-heavy sessions by 20-30%.
+//
+// When stdin carries no current_usage — Claude Code omits it right after a
+// compaction, until the next API call — the transcript's ContextTokens is
+// used instead. A compaction resets that to the compacted window, so the
+// percentage drops with it rather than echoing the pre-compaction peak.
+// summary may be nil.
+func ContextTokens(input *StatusLineInput, summary *TranscriptSummary) (used, window int) {
+	used = input.ContextWindow.CurrentUsage.InputTokens +
+		input.ContextWindow.CurrentUsage.CacheReadInputTokens +
+		input.ContextWindow.CurrentUsage.CacheCreationInputTokens +
+		input.ContextWindow.CurrentUsage.OutputTokens
+	if used == 0 && summary != nil {
+		used = summary.ContextTokens
+	}
+	window = input.ContextWindow.ContextWindowSize
+	if window == 0 {
+		window = config.GetContextWindow(input.Model.ID)
+	}
+	return used, window
+}
+
 // contextColor picks the ANSI colour code for the context bar. It dispatches
 // on maxTokens so the user sees the right warning for their actual window:
 //
@@ -78,16 +144,49 @@ func contextColor(tokens, maxTokens int) string {
 	return contextAbsoluteColor(tokens)
 }
 
+// contextWarningPct / contextCriticalPct are the yellow and red breakpoints
+// of contextPercentColor. Defaults match config.DefaultWarningThreshold /
+// DefaultCriticalThreshold; replaced via SetContextThresholds from main.
+var (
+	contextWarningPct  = defaultContextWarningPct
+	contextCriticalPct = defaultContextCriticalPct
+	contextThresholdMu sync.RWMutex
+)
+
+const (
+	defaultContextWarningPct  = 60
+	defaultContextCriticalPct = 75
+)
+
+// SetContextThresholds sets the context percentages where the token bar
+// turns yellow and red. Callers validate the pair (see
+// config.GetThresholds). Thread-safe.
+func SetContextThresholds(warning, critical int) {
+	contextThresholdMu.Lock()
+	defer contextThresholdMu.Unlock()
+	contextWarningPct = warning
+	contextCriticalPct = critical
+}
+
+// getContextThresholds returns the current yellow and red breakpoints.
+func getContextThresholds() (warning, critical float64) {
+	contextThresholdMu.RLock()
+	defer contextThresholdMu.RUnlock()
+	return float64(contextWarningPct), float64(contextCriticalPct)
+}
+
 // contextPercentColor maps a context-window utilisation percentage to its
 // ANSI colour code (5 tiers). Used only for windows at or under
 // standardContextWindowSize (200K) — see contextColor for the dispatch rule.
-// The thresholds are tuned for AutoCompact at 85%: red at 75% gives ~2 turns
-// of warning before compaction fires.
+// The default breakpoints are tuned for AutoCompact at 85%: red at 75% gives
+// ~2 turns of warning before compaction fires. The yellow and red ones are
+// configurable via format.thresholds; the lower tiers are fixed.
 func contextPercentColor(pct float64) string {
+	warning, critical := getContextThresholds()
 	switch {
-	case pct >= 75:
+	case pct >= critical:
 		return "\x1b[1;31m" // red: AutoCompact imminent
-	case pct >= 60:
+	case pct >= warning:
 		return "\x1b[1;33m" // yellow: close to warning zone
 	case pct >= 40:
 		return "\x1b[1;36m" // cyan: past halfway
@@ -102,14 +201,20 @@ func contextPercentColor(pct float64) string {
 // 200K mark — where context length starts to degrade speed and inflate cost
 // even though the hard cap is far away — lands in yellow ("you should
 // compress"), and 250K escalates to red ("compress now"). 180K is the first
-// heads-up because below it the user has comfortable headroom.
+// heads-up because below it the user has comfortable headroom. Those tiers
+// match the default format.thresholds; custom thresholds scale them by the
+// same ratio, so critical: 90 moves red from 250K to 300K.
 func contextAbsoluteColor(tokens int) string {
+	warning, critical := getContextThresholds()
+	warnScale := warning / defaultContextWarningPct
+	critScale := critical / defaultContextCriticalPct
+	t := float64(tokens)
 	switch {
-	case tokens >= 250_000:
+	case t >= 250_000*critScale:
 		return "\x1b[1;31m" // red: compress NOW
-	case tokens >= 200_000:
+	case t >= 200_000*warnScale:
 		return "\x1b[1;33m" // yellow: should compress soon
-	case tokens >= 180_000:
+	case t >= 180_000*warnScale:
 		return "\x1b[1;36m" // cyan: closing in on 200K
 	}
 	return "\x1b[1;32m" // green: plenty of room
@@ -121,31 +226,16 @@ func (c *TokenBarCollector) Collect(input interface{}, summary interface{}) (str
 	if !ok {
 		return "", fmt.Errorf("invalid input type")
 	}
-	tokens := statusInput.ContextWindow.CurrentUsage.InputTokens +
-		statusInput.ContextWindow.CurrentUsage.CacheReadInputTokens +
-		statusInput.ContextWindow.CurrentUsage.OutputTokens
-	maxTokens := statusInput.ContextWindow.ContextWindowSize
-	if maxTokens == 0 {
-		maxTokens = standardContextWindowSize
-	}
+	transcriptSummary, _ := summary.(*TranscriptSummary)
+	tokens, maxTokens := ContextTokens(statusInput, transcriptSummary)
 	pct := float64(tokens) / float64(maxTokens) * 100
 
-	barWidth := 10
-	fillWidth := int(pct / 100 * float64(barWidth))
-	if fillWidth > barWidth {
-		fillWidth = barWidth
-	}
 	// Any non-zero usage must paint at least one filled block, otherwise the
 	// tier colour is invisible. This matters most on the 1M extended window,
 	// where 9% of 1M (already 90K tokens — not nothing) would otherwise
-	// truncate to fillWidth=0 and the bar would render as a bare "░░░░░░░░░░"
-	// with no green/cyan/yellow signal at all. Pinned by
-	// TestTokenBarCollector_MinimumFillWhenUsed.
-	if fillWidth == 0 && tokens > 0 {
-		fillWidth = 1
-	}
-	filled := strings.Repeat("█", fillWidth)
-	empty := strings.Repeat("░", barWidth-fillWidth)
+	// truncate to an empty bar with no green/cyan/yellow signal at all.
+	// Pinned by TestTokenBarCollector_MinimumFillWhenUsed.
+	filled, empty := renderBar(pct, getBarWidth(), getProgressBarStyle(), tokens > 0)
 
 	return fmt.Sprintf("[%s%s\x1b[0m%s]", contextColor(tokens, maxTokens), filled, empty), nil
 }
@@ -155,6 +245,34 @@ type TokenInfoCollector struct {
 	*BaseCollector
 }
 
+// showCachedTokens controls whether token-info annotates the used count with
+// the cached share (cache reads + cache writes), e.g. "60.0K (45.0K
+// cached)/200K". Off by default and replaced via SetShowCachedTokens from
+// main once the YAML config is loaded.
+var (
+	showCachedTokens   bool
+	showCachedTokensMu sync.RWMutex
+)
+
+// SetShowCachedTokens enables or disables the cached-token annotation on the
+// token-info cell. Thread-safe.
+func SetShowCachedTokens(enabled bool) {
+	showCachedTokensMu.Lock()
+	defer showCachedTokensMu.Unlock()
+	showCachedTokens = enabled
+}
+
+// cachedAnnotationThreshold is the smallest cached share worth annotating;
+// below it "(312 cached)" is noise next to a six-figure total.
+const cachedAnnotationThreshold = 1_000
+
+// getShowCachedTokens returns whether the cached-token annotation is enabled.
+func getShowCachedTokens() bool {
+	showCachedTokensMu.RLock()
+	defer showCachedTokensMu.RUnlock()
+	return showCachedTokens
+}
+
 // NewTokenInfoCollector creates a new token info collector
 func NewTokenInfoCollector() *TokenInfoCollector {
 	return &TokenInfoCollector{
@@ -171,16 +289,17 @@ func (c *TokenInfoCollector) Collect(input interface{}, summary interface{}) (st
 	if !ok {
 		return "", fmt.Errorf("invalid input type")
 	}
-	tokens := statusInput.ContextWindow.CurrentUsage.InputTokens +
-		statusInput.ContextWindow.CurrentUsage.CacheReadInputTokens +
-		statusInput.ContextWindow.CurrentUsage.OutputTokens
-	maxTokens := statusInput.ContextWindow.ContextWindowSize
-	if maxTokens == 0 {
-		maxTokens = standardContextWindowSize
-	}
+	transcriptSummary, _ := summary.(*TranscriptSummary)
+	tokens, maxTokens := ContextTokens(statusInput, transcriptSummary)
 	pct := float64(tokens) / float64(maxTokens) * 100
 
-	return fmt.Sprintf("%s/%dK (%s%.1f%%\x1b[0m)", formatNumber(tokens), maxTokens/1000, contextColor(tokens, maxTokens), pct), nil
+	used := formatNumber(tokens)
+	usage := statusInput.ContextWindow.CurrentUsage
+	if cached := usage.CacheReadInputTokens + usage.CacheCreationInputTokens; cached >= cachedAnnotationThreshold && getShowCachedTokens() {
+		used = fmt.Sprintf("%s (%s cached)", used, formatNumber(cached))
+	}
+
+	return fmt.Sprintf("%s/%dK (%s%.1f%%\x1b[0m)", used, maxTokens/1000, contextColor(tokens, maxTokens), pct), nil
 }
 
 // formatNumber formats a number with K/M suffixes
@@ -207,7 +326,9 @@ func NewSessionTotalCollector() *SessionTotalCollector {
 	}
 }
 
-// Collect returns session total cost and token usage
+// Collect returns session total cost and token usage, e.g.
+// "💰 $7.23 +1200/-108 lines · I:587.9K O:60.0K". The cost part is the cost
+// segment (see costSegment) and is omitted at zero cost.
 func (c *SessionTotalCollector) Collect(input interface{}, summary interface{}) (string, error) {
 	statusInput, ok := input.(*StatusLineInput)
 	if !ok {
@@ -215,11 +336,13 @@ func (c *SessionTotalCollector) Collect(input interface{}, summary interface{})
 	}
 	totalIn := statusInput.ContextWindow.TotalInputTokens
 	totalOut := statusInput.ContextWindow.TotalOutputTokens
-	cost := statusInput.Cost.TotalCostUSD
 
-	if totalIn == 0 && totalOut == 0 && cost == 0 {
-		return "", nil
+	var parts []string
+	if cost := costSegment(statusInput); cost != "" {
+		parts = append(parts, cost)
 	}
-
-	return fmt.Sprintf("\U0001f4b0 $%.2f \u00b7 I:%s O:%s", cost, formatNumber(totalIn), formatNumber(totalOut)), nil
+	if totalIn > 0 || totalOut > 0 {
+		parts = append(parts, fmt.Sprintf("I:%s O:%s", formatNumber(totalIn), formatNumber(totalOut)))
+	}
+	return strings.Join(parts, " \u00b7 "), nil
 }
@@ -196,6 +196,147 @@ func TestTokenInfoCollector_Collect(t *testing.T) {
 	}
 }
 
+// TestTokenInfoCollector_ModelAwareWindow verifies the window is looked up
+// from the model ID when the host omits context_window_size, so both the
+// label and the percentage reflect the real window.
+func TestTokenInfoCollector_ModelAwareWindow(t *testing.T) {
+	collector := NewTokenInfoCollector()
+
+	tests := []struct {
+		name    string
+		modelID string
+		want    string
+	}{
+		{"1M beta model", "claude-sonnet-4-5[1m]", "100.0K/1000K (10.0%"},
+		{"GLM 4.5", "glm-4.5", "100.0K/128K (78.1%"},
+		{"unknown model falls back to 200K", "mystery-model", "100.0K/200K (50.0%"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			input := makeStatusInput(100000, 0, 0, 0)
+			input.Model.ID = tt.modelID
+
+			// Act
+			got, err := collector.Collect(input, nil)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Contains(t, stripANSI(got), tt.want)
+		})
+	}
+}
+
+// TestTokenInfoCollector_CachedAnnotation verifies the opt-in "(X cached)"
+// annotation: shown only when enabled and the cached share (reads + writes)
+// reaches cachedAnnotationThreshold.
+func TestTokenInfoCollector_CachedAnnotation(t *testing.T) {
+	collector := NewTokenInfoCollector()
+	t.Cleanup(func() { SetShowCachedTokens(false) })
+
+	tests := []struct {
+		name    string
+		enabled bool
+		input   *StatusLineInput
+		want    string
+		notWant string
+	}{
+		{"enabled with cache reads", true, makeStatusInput(10000, 45000, 5000, 200000), "60.0K (45.0K cached)/200K", ""},
+		{"enabled without cache reads", true, makeStatusInput(10000, 0, 5000, 200000), "15.0K/200K", "cached"},
+		{"disabled by default", false, makeStatusInput(10000, 45000, 5000, 200000), "60.0K/200K", "cached"},
+		{"cache writes included", true, withCacheCreation(makeStatusInput(10000, 45000, 5000, 200000), 15000), "75.0K (60.0K cached)/200K", ""},
+		{"below threshold", true, makeStatusInput(10000, 500, 5000, 200000), "15.5K/200K", "cached"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			SetShowCachedTokens(tt.enabled)
+
+			// Act
+			got, err := collector.Collect(tt.input, nil)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Contains(t, got, tt.want)
+			if tt.notWant != "" {
+				assert.NotContains(t, got, tt.notWant)
+			}
+		})
+	}
+}
+
+// TestTokenBarCollector_BarWidthConfig verifies format.barWidth resizes the
+// bar and STATUSLINE_BAR_WIDTH takes precedence over it.
+func TestTokenBarCollector_BarWidthConfig(t *testing.T) {
+	SetProgressBarStyle(barStyleBlock) // counts █ / ░ cells
+	t.Cleanup(func() {
+		SetBarWidth(defaultBarWidth)
+		SetProgressBarStyle(barStyleBraille)
+	})
+	collector := NewTokenBarCollector()
+
+	tests := []struct {
+		name      string
+		width     int
+		env       string
+		wantCells int
+	}{
+		{"configured width", 24, "", 24},
+		{"env overrides config", 24, "8", 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			SetBarWidth(tt.width)
+			t.Setenv("STATUSLINE_BAR_WIDTH", tt.env)
+
+			// Act
+			got, err := collector.Collect(makeStatusInput(50000, 0, 0, 200000), nil)
+
+			// Assert
+			require.NoError(t, err)
+			cells := strings.Count(got, "█") + strings.Count(got, "░")
+			assert.Equal(t, tt.wantCells, cells)
+		})
+	}
+}
+
+// TestTokenBarCollector_BarWidthEnv verifies STATUSLINE_BAR_WIDTH resizes the
+// bar, clamped to [4, 40].
+func TestTokenBarCollector_BarWidthEnv(t *testing.T) {
+	SetProgressBarStyle(barStyleBlock) // counts █ / ░ cells
+	t.Cleanup(func() { SetProgressBarStyle(barStyleBraille) })
+	collector := NewTokenBarCollector()
+
+	tests := []struct {
+		name      string
+		env       string
+		wantCells int
+	}{
+		{"default width", "", 10},
+		{"custom width", "20", 20},
+		{"clamped to max", "100", 40},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			t.Setenv("STATUSLINE_BAR_WIDTH", tt.env)
+
+			// Act
+			got, err := collector.Collect(makeStatusInput(50000, 0, 0, 200000), nil)
+
+			// Assert
+			require.NoError(t, err)
+			cells := strings.Count(got, "█") + strings.Count(got, "░")
+			assert.Equal(t, tt.wantCells, cells)
+		})
+	}
+}
+
 // TestContextPercentColor pins the 5-tier mapping for the context-window
 // scale. These thresholds intentionally differ from the quota scale (see
 // quotaPercentColor): for context the percentage rising IS the warning, so
@@ -336,6 +477,8 @@ func TestTokenBarCollector_ExtendedWindow(t *testing.T) {
 // fill to zero and leaves the bar rendered as bare "░░░░░░░░░░" with no
 // colour at all — see the comment in TokenBarCollector.Collect.
 func TestTokenBarCollector_MinimumFillWhenUsed(t *testing.T) {
+	SetProgressBarStyle(barStyleBlock) // counts █ / ░ cells
+	t.Cleanup(func() { SetProgressBarStyle(barStyleBraille) })
 	collector := NewTokenBarCollector()
 
 	t.Run("1M window 9% usage still paints one green block", func(t *testing.T) {
@@ -364,7 +507,7 @@ func TestTokenBarCollector_MinimumFillWhenUsed(t *testing.T) {
 		input := makeStatusInput(10_000, 0, 0, 200_000)
 		got, err := collector.Collect(input, nil)
 		require.NoError(t, err)
-			assert.Contains(t, got, "\x1b[1;92m", "bright green tier must be applied")
+		assert.Contains(t, got, "\x1b[1;92m", "bright green tier must be applied")
 		assert.Contains(t, got, "█", "must paint at least one filled block")
 	})
 }
@@ -490,6 +633,12 @@ func TestFormatNumber(t *testing.T) {
 }
 
 // makeStatusInput is a test helper that creates a StatusLineInput with specified token values.
+// withCacheCreation sets cache_creation_input_tokens on a makeStatusInput result.
+func withCacheCreation(input *StatusLineInput, cacheCreationTokens int) *StatusLineInput {
+	input.ContextWindow.CurrentUsage.CacheCreationInputTokens = cacheCreationTokens
+	return input
+}
+
 func makeStatusInput(inputTokens, cacheTokens, outputTokens, contextWindowSize int) *StatusLineInput {
 	input := &StatusLineInput{}
 	input.ContextWindow.CurrentUsage.InputTokens = inputTokens
@@ -507,6 +656,8 @@ func TestSessionTotalCollector_Collect(t *testing.T) {
 		totalIn   int
 		totalOut  int
 		costUSD   float64
+		added     int
+		removed   int
 		want      string
 		wantEmpty bool
 	}{
@@ -528,15 +679,24 @@ func TestSessionTotalCollector_Collect(t *testing.T) {
 			name:     "small session",
 			totalIn:  500,
 			totalOut: 100,
-			costUSD:  0.01,
-			want:     "\U0001f4b0 $0.01 \u00b7 I:500 O:100",
+			costUSD:  0.0123,
+			want:     "\U0001f4b0 $0.0123 \u00b7 I:500 O:100",
+		},
+		{
+			name:     "lines changed",
+			totalIn:  587879,
+			totalOut: 60025,
+			costUSD:  7.23,
+			added:    1200,
+			removed:  108,
+			want:     "\U0001f4b0 $7.23 +1200/-108 lines \u00b7 I:587.9K O:60.0K",
 		},
 		{
-			name:     "zero cost with tokens",
+			name:     "zero cost omits the cost segment",
 			totalIn:  1000,
 			totalOut: 200,
 			costUSD:  0,
-			want:     "\U0001f4b0 $0.00 \u00b7 I:1.0K O:200",
+			want:     "I:1.0K O:200",
 		},
 		{
 			name:      "all zero returns empty",
@@ -554,6 +714,8 @@ func TestSessionTotalCollector_Collect(t *testing.T) {
 			input.ContextWindow.TotalInputTokens = tt.totalIn
 			input.ContextWindow.TotalOutputTokens = tt.totalOut
 			input.Cost.TotalCostUSD = tt.costUSD
+			input.Cost.TotalLinesAdded = tt.added
+			input.Cost.TotalLinesRemoved = tt.removed
 
 			// Act
 			got, err := collector.Collect(input, nil)
@@ -588,3 +750,142 @@ func TestSessionTotalCollector_Properties(t *testing.T) {
 	assert.Equal(t, ContentSessionTotal, collector.Type())
 	assert.True(t, collector.Optional())
 }
+
+// TestContextTokens_IncludesCacheCreation verifies cache writes count toward
+// the context total alongside input, cache reads and output.
+func TestContextTokens_IncludesCacheCreation(t *testing.T) {
+	tests := []struct {
+		name  string
+		input *StatusLineInput
+		want  int
+	}{
+		{"no cache writes", makeStatusInput(5000, 3000, 1000, 200000), 9000},
+		{"with cache writes", withCacheCreation(makeStatusInput(5000, 3000, 1000, 200000), 40000), 49000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			used, _ := ContextTokens(tt.input, nil)
+
+			// Assert
+			assert.Equal(t, tt.want, used)
+		})
+	}
+}
+
+// TestContextTokens_TranscriptFallback verifies the transcript's context
+// count stands in only when stdin reports no current_usage.
+func TestContextTokens_TranscriptFallback(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   *StatusLineInput
+		summary *TranscriptSummary
+		want    int
+	}{
+		{"stdin wins", makeStatusInput(5000, 3000, 1000, 200000), &TranscriptSummary{ContextTokens: 150000}, 9000},
+		{"no stdin usage uses transcript", makeStatusInput(0, 0, 0, 200000), &TranscriptSummary{ContextTokens: 23500}, 23500},
+		{"compacted with no reply yet", makeStatusInput(0, 0, 0, 200000), &TranscriptSummary{Compactions: 1}, 0},
+		{"nil summary", makeStatusInput(0, 0, 0, 200000), nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			used, _ := ContextTokens(tt.input, tt.summary)
+
+			// Assert
+			assert.Equal(t, tt.want, used)
+		})
+	}
+}
+
+// TestTokenCollectors_OverflowClampsBar verifies usage past the window fills
+// the bar exactly once while the info text still prints the real percentage.
+func TestTokenCollectors_OverflowClampsBar(t *testing.T) {
+	SetProgressBarStyle(barStyleBlock) // counts █ / ░ cells
+	t.Cleanup(func() { SetProgressBarStyle(barStyleBraille) })
+	// Arrange
+	input := withCacheCreation(makeStatusInput(150000, 50000, 10000, 200000), 30000)
+
+	// Act
+	bar, barErr := NewTokenBarCollector().Collect(input, nil)
+	info, infoErr := NewTokenInfoCollector().Collect(input, nil)
+
+	// Assert
+	require.NoError(t, barErr)
+	require.NoError(t, infoErr)
+	assert.Equal(t, 10, strings.Count(bar, "█"))
+	assert.Equal(t, 0, strings.Count(bar, "░"))
+	assert.Contains(t, info, "120.0%")
+}
+
+// TestContextPercentColor_CustomThresholds verifies format.thresholds moves
+// the yellow and red breakpoints while the lower tiers stay fixed.
+func TestContextPercentColor_CustomThresholds(t *testing.T) {
+	t.Cleanup(func() { SetContextThresholds(60, 75) })
+
+	tests := []struct {
+		name     string
+		warning  int
+		critical int
+		pct      float64
+		want     string
+	}{
+		{"default red at 75", 60, 75, 75, "\x1b[1;31m"},
+		{"default yellow at 60", 60, 75, 60, "\x1b[1;33m"},
+		{"critical 90 not red at 80", 85, 90, 80, "\x1b[1;36m"},
+		{"critical 90 red at 90", 85, 90, 90, "\x1b[1;31m"},
+		{"warning 85 cyan at 70", 85, 90, 70, "\x1b[1;36m"},
+		{"lower tiers unchanged", 85, 90, 25, "\x1b[1;32m"},
+		// A lone critical: 90 keeps the default warning of 60, so the bar is
+		// already yellow before it turns red.
+		{"lone critical 90 yellow at 80", 60, 90, 80, "\x1b[1;33m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			SetContextThresholds(tt.warning, tt.critical)
+
+			// Act
+			got := contextPercentColor(tt.pct)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestContextAbsoluteColor_CustomThresholds verifies format.thresholds also
+// reaches extended windows, scaling the absolute tiers by the same ratio.
+func TestContextAbsoluteColor_CustomThresholds(t *testing.T) {
+	t.Cleanup(func() { SetContextThresholds(60, 75) })
+
+	tests := []struct {
+		name     string
+		warning  int
+		critical int
+		tokens   int
+		want     string
+	}{
+		{"critical 90 not red at 250K", 60, 90, 250_000, "\x1b[1;33m"},
+		{"critical 90 red at 300K", 60, 90, 300_000, "\x1b[1;31m"},
+		{"warning 30 yellow at 100K", 30, 75, 100_000, "\x1b[1;33m"},
+		{"warning 30 cyan at 90K", 30, 75, 90_000, "\x1b[1;36m"},
+		{"warning 30 green below 90K", 30, 75, 89_999, "\x1b[1;32m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			SetContextThresholds(tt.warning, tt.critical)
+
+			// Act
+			got := contextAbsoluteColor(tt.tokens)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package content
+
+import (
+	"strings"
+	"sync"
+)
+
+// Progress bar styles accepted by format.progressBar.
+const (
+	barStyleBlock    = "block"
+	barStyleBraille  = "braille"
+	barStyleASCII    = "ascii"
+	barStyleGradient = "gradient"
+)
+
+// gradientGlyphs are the partial-cell glyphs for 1/8 … 7/8 fill, used by the
+// gradient style for the cell where the fill ends.
+var gradientGlyphs = []string{"▏", "▎", "▍", "▌", "▋", "▊", "▉"}
+
+// brailleGlyphs fill a braille cell dot by dot, from the empty-track ⣀ (0/6)
+// to the full ⣿ (6/6).
+var brailleGlyphs = []string{"⣀", "⣄", "⣤", "⣦", "⣶", "⣷", "⣿"}
+
+// ProgressBarRenderer draws a bar of width cells filled to pct (0-100). It
+// returns the filled and empty parts separately so the caller can colour the
+// filled part. When used is true a non-zero fill is always visible.
+type ProgressBarRenderer interface {
+	Render(pct float64, width int, used bool) (filled, empty string)
+}
+
+// cellBar fills whole cells with one glyph and pads with another: █/░ for
+// the block style, #/- for ASCII.
+type cellBar struct {
+	fill, track string
+}
+
+func (b cellBar) Render(pct float64, width int, used bool) (filled, empty string) {
+	cells := int(pct / 100 * float64(width))
+	if cells == 0 && used {
+		cells = 1
+	}
+	return strings.Repeat(b.fill, cells), strings.Repeat(b.track, width-cells)
+}
+
+// partialBar splits each cell into len(partials)+1 steps so the cell where
+// the fill ends shows a partial glyph.
+type partialBar struct {
+	full, track string
+	partials    []string // glyphs for 1 … steps-1 steps of fill
+}
+
+func (b partialBar) Render(pct float64, width int, used bool) (filled, empty string) {
+	steps := len(b.partials) + 1
+	units := int(pct / 100 * float64(width*steps))
+	if units == 0 && used {
+		units = 1
+	}
+	full, rem := units/steps, units%steps
+	filled = strings.Repeat(b.full, full)
+	cells := full
+	if rem > 0 {
+		filled += b.partials[rem-1]
+		cells++
+	}
+	return filled, strings.Repeat(b.track, width-cells)
+}
+
+// progressBarRenderers maps each format.progressBar style to its renderer.
+var progressBarRenderers = map[string]ProgressBarRenderer{
+	barStyleBlock:    cellBar{fill: "█", track: "░"},
+	barStyleASCII:    cellBar{fill: "#", track: "-"},
+	barStyleBraille:  partialBar{full: brailleGlyphs[6], track: brailleGlyphs[0], partials: brailleGlyphs[1:6]},
+	barStyleGradient: partialBar{full: "█", track: "░", partials: gradientGlyphs},
+}
+
+// progressBarStyle is the configured bar style; set via SetProgressBarStyle
+// from main.
+var (
+	progressBarStyle   = barStyleBraille
+	progressBarStyleMu sync.RWMutex
+)
+
+// SetProgressBarStyle sets the token bar style from format.progressBar
+// (config.GetProgressBarStyle). Thread-safe.
+func SetProgressBarStyle(style string) {
+	progressBarStyleMu.Lock()
+	defer progressBarStyleMu.Unlock()
+	progressBarStyle = style
+}
+
+func getProgressBarStyle() string {
+	progressBarStyleMu.RLock()
+	defer progressBarStyleMu.RUnlock()
+	return progressBarStyle
+}
+
+// renderBar splits a bar of width cells at pct (clamped to 0-100) using the
+// renderer for style; unknown styles fall back to braille. When used is true a
+// non-zero fill is always visible, otherwise small usage on a large window
+// truncates to an empty bar with no colour signal.
+func renderBar(pct float64, width int, style string, used bool) (filled, empty string) {
+	if pct < 0 {
+		pct = 0
+	}
+	if pct > 100 {
+		pct = 100
+	}
+	r, ok := progressBarRenderers[style]
+	if !ok {
+		r = progressBarRenderers[barStyleBraille]
+	}
+	return r.Render(pct, width, used)
+}
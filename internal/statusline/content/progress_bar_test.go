@@ -0,0 +1,119 @@
+package content
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderBar_Gradient(t *testing.T) {
+	tests := []struct {
+		name       string
+		pct        float64
+		used       bool
+		wantFilled string
+		wantEmpty  string
+	}{
+		{"37% shows a 5/8 partial cell", 37, true, "███▋", "░░░░░░"},
+		{"exact cell boundary has no partial glyph", 50, true, "█████", "░░░░░"},
+		{"full", 100, true, "██████████", ""},
+		{"overflow clamps to full", 140, true, "██████████", ""},
+		{"tiny usage shows one eighth", 0.5, true, "▏", "░░░░░░░░░"},
+		{"zero usage stays empty", 0, false, "", "░░░░░░░░░░"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			filled, empty := renderBar(tt.pct, 10, barStyleGradient, tt.used)
+
+			// Assert
+			assert.Equal(t, tt.wantFilled, filled)
+			assert.Equal(t, tt.wantEmpty, empty)
+		})
+	}
+}
+
+func TestRenderBar_DefaultStyleWholeCells(t *testing.T) {
+	// Act
+	filled, empty := renderBar(37, 10, barStyleBlock, true)
+
+	// Assert
+	assert.Equal(t, "███", filled)
+	assert.Equal(t, "░░░░░░░", empty)
+}
+
+func TestRenderBar_Styles(t *testing.T) {
+	tests := []struct {
+		style string
+		pct   float64
+		want  string
+	}{
+		{barStyleBlock, 0, "░░░░░░░░░░"},
+		{barStyleBlock, 33, "███░░░░░░░"},
+		{barStyleBlock, 50, "█████░░░░░"},
+		{barStyleBlock, 99, "█████████░"},
+		{barStyleBlock, 100, "██████████"},
+		{barStyleASCII, 0, "----------"},
+		{barStyleASCII, 33, "###-------"},
+		{barStyleASCII, 50, "#####-----"},
+		{barStyleASCII, 99, "#########-"},
+		{barStyleASCII, 100, "##########"},
+		{barStyleBraille, 0, "⣀⣀⣀⣀⣀⣀⣀⣀⣀⣀"},
+		{barStyleBraille, 33, "⣿⣿⣿⣄⣀⣀⣀⣀⣀⣀"},
+		{barStyleBraille, 50, "⣿⣿⣿⣿⣿⣀⣀⣀⣀⣀"},
+		{barStyleBraille, 99, "⣿⣿⣿⣿⣿⣿⣿⣿⣿⣷"},
+		{barStyleBraille, 100, "⣿⣿⣿⣿⣿⣿⣿⣿⣿⣿"},
+		{barStyleGradient, 0, "░░░░░░░░░░"},
+		{barStyleGradient, 33, "███▎░░░░░░"},
+		{barStyleGradient, 50, "█████░░░░░"},
+		{barStyleGradient, 99, "█████████▉"},
+		{barStyleGradient, 100, "██████████"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/%v%%", tt.style, tt.pct), func(t *testing.T) {
+			// Act
+			filled, empty := renderBar(tt.pct, 10, tt.style, tt.pct > 0)
+
+			// Assert
+			assert.Equal(t, tt.want, filled+empty)
+		})
+	}
+}
+
+func TestRenderBar_MinimumFillWhenUsed(t *testing.T) {
+	for _, style := range []string{barStyleBlock, barStyleASCII, barStyleBraille, barStyleGradient} {
+		t.Run(style, func(t *testing.T) {
+			// Act
+			filled, _ := renderBar(0.1, 10, style, true)
+
+			// Assert
+			assert.NotEmpty(t, filled)
+		})
+	}
+}
+
+func TestRenderBar_UnknownStyleFallsBackToBraille(t *testing.T) {
+	// Act
+	filled, empty := renderBar(50, 4, "sparkles", false)
+
+	// Assert
+	assert.Equal(t, "⣿⣿⣀⣀", filled+empty)
+}
+
+func TestTokenBarCollector_GradientStyle(t *testing.T) {
+	// Arrange
+	SetProgressBarStyle(barStyleGradient)
+	t.Cleanup(func() { SetProgressBarStyle(barStyleBraille) })
+	t.Setenv("STATUSLINE_BAR_WIDTH", "")
+
+	// Act: 74K of 200K = 37%
+	got, err := NewTokenBarCollector().Collect(makeStatusInput(74_000, 0, 0, 200_000), nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "███▋░░░░░░", stripANSI(got[1:len(got)-1]))
+}
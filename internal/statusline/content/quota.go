@@ -53,6 +53,13 @@ type UsageData struct {
 	APIUnavailable  bool
 	APIError        string
 
+	// OneHour is the short rolling window some accounts report alongside
+	// 5h/7d. HasOneHour distinguishes "absent from the response" from "0%",
+	// so accounts without it keep the legacy two-window line.
+	OneHour        float64
+	OneHourResetAt time.Time
+	HasOneHour     bool
+
 	// Provider tags the source so renderer/cache can branch on it. Values:
 	// "anthropic", "glm-zhipu", "glm-zai". Empty is treated as "anthropic" so
 	// pre-existing cache files load without migration.
@@ -120,6 +127,64 @@ func (c *QuotaCollector) Collect(input interface{}, summary interface{}) (string
 	return getSubscriptionQuota(statusInput), nil
 }
 
+// QuotaDualCollector is a compact alternative to QuotaCollector that puts
+// the 5h and 7d utilization side by side: "📊 5h 42% · 7d 18%". It is not
+// registered by default; content.use.quota: quota-dual swaps it in for the
+// quota collector, so it reads the same usage cache and never adds a request.
+type QuotaDualCollector struct {
+	*BaseCollector
+}
+
+// NewQuotaDualCollector creates a new dual-window quota collector
+func NewQuotaDualCollector() *QuotaDualCollector {
+	return &QuotaDualCollector{
+		BaseCollector: NewBaseCollectorWithTimeout(ContentQuotaDual, 5*time.Minute, 4*time.Second, true),
+	}
+}
+
+// Collect returns the dual-window quota gauge
+func (c *QuotaDualCollector) Collect(input interface{}, summary interface{}) (string, error) {
+	statusInput, ok := input.(*StatusLineInput)
+	if !ok {
+		return "", fmt.Errorf("invalid input type")
+	}
+	return getDualQuota(statusInput), nil
+}
+
+// getDualQuota renders "📊 5h 42% · 7d 18%". A window counts as available
+// when it has usage or a known reset time; with only one available it shows
+// just that one. Anthropic accounts with neither keep the legacy "both at
+// 0%" shape, matching getSubscriptionQuota.
+func getDualQuota(input *StatusLineInput) string {
+	var usage *UsageData
+	if getSubscriptionUsageFn != nil {
+		usage = getSubscriptionUsageFn()
+	} else {
+		usage = getSubscriptionUsage(input)
+	}
+	if usage == nil {
+		return ""
+	}
+
+	has5h := usage.FiveHour > 0 || !usage.FiveHourResetAt.IsZero()
+	has7d := usage.SevenDay > 0 || !usage.SevenDayResetAt.IsZero()
+	if !has5h && !has7d && (usage.Provider == "" || usage.Provider == "anthropic") {
+		has5h, has7d = true, true
+	}
+
+	parts := make([]string, 0, 2)
+	if has5h {
+		parts = append(parts, "5h "+colouredPercent(usage.FiveHour))
+	}
+	if has7d {
+		parts = append(parts, "7d "+colouredPercent(usage.SevenDay))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "📊 " + strings.Join(parts, " · ")
+}
+
 // getSubscriptionUsage dispatches to the right provider's usage fetcher based
 // on $ANTHROPIC_BASE_URL. Returns nil for "custom" third-party proxies — we
 // have no way to query their quota.
@@ -174,6 +239,10 @@ func formatResetCountdown(d time.Duration) string {
 //     📊 [Pro] 22% 5h ↻ 4h32m · 2% 7d           // only 5h reset known
 //     📊 0% 5h · 0% 7d                          // API user (no PlanLevel)
 //
+//     When the API also reports a one-hour window it is the tightest limit
+//     the user can hit, so it leads the list:
+//     📊 [Max] 64% 1h ↻ 23m · 22% 5h ↻ 4h32m · 2% 7d ↻ 1d22h
+//
 //   - GLM (glm-zhipu / glm-zai): renders only the windows actually present
 //     on the plan, plus an MCP segment when applicable. MCP counts are
 //     compacted with k/M suffixes; the 🧩 glyph stands in for the literal
@@ -210,7 +279,13 @@ func getSubscriptionQuota(input *StatusLineInput) string {
 	if usage.Provider == "glm-zai" || usage.Provider == "glm-zhipu" {
 		glmHas5h, glmHas7d = glmPlanWindows(usage.PlanLevel)
 	}
-	parts := make([]string, 0, 5)
+	parts := make([]string, 0, 6)
+
+	// 1h: only when the provider actually reported it. Shortest window
+	// first — it is the one that throttles soonest.
+	if usage.HasOneHour {
+		parts = append(parts, formatPercentWindow(usage.OneHour, "1h", usage.OneHourResetAt, now))
+	}
 
 	// 5h: rendered when Anthropic (legacy invariant), the GLM plan is known
 	// to have a 5h window, or there's live data / a known reset time.
@@ -1,6 +1,7 @@
 package content
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -24,6 +25,12 @@ type CredentialsFile struct {
 
 // UsageApiResponse represents the OAuth usage API response
 type UsageApiResponse struct {
+	// OneHour is only reported for some accounts; nil leaves the rendered
+	// line identical to the 5h/7d-only shape.
+	OneHour *struct {
+		Utilization float64 `json:"utilization"`
+		ResetsAt    string  `json:"resets_at"`
+	} `json:"one_hour"`
 	FiveHour *struct {
 		Utilization float64 `json:"utilization"`
 		ResetsAt    string  `json:"resets_at"`
@@ -189,7 +196,10 @@ func getAnthropicUsageFromAPI() *UsageData {
 func fetchUsageAPI(accessToken string) (*UsageData, bool, int, error) {
 	client := newClaudeHTTPClient(time.Duration(httpTimeoutSeconds) * time.Second)
 
-	req, err := http.NewRequest("GET", usageAPIURL, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), usageAPIBudget)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", usageAPIURL, nil)
 	if err != nil {
 		return nil, false, 0, err
 	}
@@ -198,7 +208,7 @@ func fetchUsageAPI(accessToken string) (*UsageData, bool, int, error) {
 	req.Header.Set("anthropic-beta", "oauth-2025-04-20")
 	req.Header.Set("User-Agent", "claude-token-monitor/1.0")
 
-	resp, err := client.Do(req)
+	resp, err := retryOnce(ctx, func() (*http.Response, error) { return client.Do(req) })
 	if err != nil {
 		return nil, false, 0, err
 	}
@@ -221,6 +231,16 @@ func fetchUsageAPI(accessToken string) (*UsageData, bool, int, error) {
 
 	usage := &UsageData{}
 
+	if apiResp.OneHour != nil {
+		usage.OneHour = apiResp.OneHour.Utilization
+		usage.HasOneHour = true
+		if apiResp.OneHour.ResetsAt != "" {
+			if t, err := time.Parse(time.RFC3339, apiResp.OneHour.ResetsAt); err == nil {
+				usage.OneHourResetAt = t
+			}
+		}
+	}
+
 	if apiResp.FiveHour != nil {
 		usage.FiveHour = apiResp.FiveHour.Utilization
 		if apiResp.FiveHour.ResetsAt != "" {
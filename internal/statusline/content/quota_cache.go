@@ -30,6 +30,7 @@ const (
 	failureCacheTTLSeconds   = 15               // Failure cache TTL
 	rateLimitBaseSeconds     = 60               // 429 base backoff
 	rateLimitMaxSeconds      = 300              // 429 max backoff (5 min)
+	networkFailureMaxSeconds = 1800             // Network failure max backoff (30 min)
 )
 
 // refreshCoordDelay is the post-mark settle window used by shouldRefreshResult
@@ -75,6 +76,9 @@ type usageCacheData struct {
 	SevenDay        float64   `json:"seven_day"`
 	FiveHourResetAt time.Time `json:"five_hour_reset_at"`
 	SevenDayResetAt time.Time `json:"seven_day_reset_at"`
+	OneHour         float64   `json:"one_hour,omitempty"`
+	OneHourResetAt  time.Time `json:"one_hour_reset_at,omitempty"`
+	HasOneHour      bool      `json:"has_one_hour,omitempty"`
 	FetchedAt       time.Time `json:"fetched_at"`
 	RefreshingSince time.Time `json:"refreshing_since,omitempty"` // Refresh start time (crash recovery)
 	APIUnavailable  bool      `json:"api_unavailable,omitempty"`
@@ -84,6 +88,10 @@ type usageCacheData struct {
 	RateLimitedCount int       `json:"rate_limited_count,omitempty"` // Consecutive 429 count
 	RetryAfterUntil  time.Time `json:"retry_after_until,omitempty"`  // Absolute time when retry is allowed
 
+	// Consecutive network failures (unreachable API or proxy); stretches the
+	// failure TTL so a dead proxy doesn't cost a timeout on every render.
+	NetworkFailureCount int `json:"network_failure_count,omitempty"`
+
 	// Last successful data (preserved during rate-limited periods)
 	LastGoodData *usageCacheData `json:"last_good_data,omitempty"`
 
@@ -263,6 +271,19 @@ func getRateLimitedTTL(count int) time.Duration {
 	return ttl
 }
 
+// getNetworkFailureTTL returns the failure TTL after count consecutive
+// network errors. Pattern: 15s, 30s, 60s, ... capped at 30 min.
+func getNetworkFailureTTL(count int) time.Duration {
+	ttl := failureCacheTTLSeconds * time.Second
+	for i := 1; i < count; i++ {
+		ttl *= 2
+		if ttl >= networkFailureMaxSeconds*time.Second {
+			return networkFailureMaxSeconds * time.Second
+		}
+	}
+	return ttl
+}
+
 // shouldRefreshResult returns refresh decision with TTL handling for the
 // given (provider, accountKey) cache file. Callers must pass their own
 // identity so the coordination state (RefreshingSince, rate-limit backoff)
@@ -290,10 +311,13 @@ func shouldRefreshResult(provider, accountKey string) (bool, *usageCacheData, bo
 	}
 
 	// Determine TTL based on cache state.
-	// Failure TTL stays short (and fixed) so transient errors recover quickly;
-	// success TTL honors the configured value (default 90s).
+	// Failure TTL starts short so transient errors recover quickly, and
+	// doubles on repeated network failures; success TTL honors the configured
+	// value (default 90s).
 	var ttl time.Duration
-	if cache.APIUnavailable || cache.APIError != "" {
+	if cache.APIError == "network" {
+		ttl = getNetworkFailureTTL(cache.NetworkFailureCount)
+	} else if cache.APIUnavailable || cache.APIError != "" {
 		ttl = time.Duration(failureCacheTTLSeconds) * time.Second
 	} else {
 		ttl = getUsageCacheTTL()
@@ -352,6 +376,9 @@ func writeRefreshedCache(usage *UsageData, oldCache *usageCacheData) error {
 		SevenDay:         usage.SevenDay,
 		FiveHourResetAt:  usage.FiveHourResetAt,
 		SevenDayResetAt:  usage.SevenDayResetAt,
+		OneHour:          usage.OneHour,
+		OneHourResetAt:   usage.OneHourResetAt,
+		HasOneHour:       usage.HasOneHour,
 		FetchedAt:        time.Now(),
 		RefreshingSince:  time.Time{}, // Clear refresh flag
 		APIUnavailable:   false,
@@ -376,6 +403,9 @@ func writeRefreshedCache(usage *UsageData, oldCache *usageCacheData) error {
 			SevenDay:        usage.SevenDay,
 			FiveHourResetAt: usage.FiveHourResetAt,
 			SevenDayResetAt: usage.SevenDayResetAt,
+			OneHour:         usage.OneHour,
+			OneHourResetAt:  usage.OneHourResetAt,
+			HasOneHour:      usage.HasOneHour,
 			Provider:        usage.Provider,
 			AccountKey:      usage.AccountKey,
 			PlanLevel:       usage.PlanLevel,
@@ -422,6 +452,7 @@ func writeRefreshFailedCache(oldCache *usageCacheData, isRateLimited bool, retry
 		if isRateLimited {
 			oldCache.APIError = "rate-limited"
 			oldCache.RateLimitedCount++
+			oldCache.NetworkFailureCount = 0
 			if retryAfterSec > 0 {
 				oldCache.RetryAfterUntil = now.Add(time.Duration(retryAfterSec) * time.Second)
 			} else {
@@ -433,6 +464,7 @@ func writeRefreshFailedCache(oldCache *usageCacheData, isRateLimited bool, retry
 			oldCache.APIUnavailable = true
 			oldCache.APIError = "network"
 			oldCache.RateLimitedCount = 0 // Reset on non-rate-limit error
+			oldCache.NetworkFailureCount++
 		}
 
 		return writeUsageCache(oldCache)
@@ -460,6 +492,8 @@ func writeRefreshFailedCache(oldCache *usageCacheData, isRateLimited bool, retry
 			ttl := getRateLimitedTTL(1)
 			cache.RetryAfterUntil = now.Add(ttl)
 		}
+	} else {
+		cache.NetworkFailureCount = 1
 	}
 	return writeUsageCache(cache)
 }
@@ -483,6 +517,9 @@ func fallbackOrNil(cache *usageCacheData) *UsageData {
 		SevenDay:        cache.SevenDay,
 		FiveHourResetAt: cache.FiveHourResetAt,
 		SevenDayResetAt: cache.SevenDayResetAt,
+		OneHour:         cache.OneHour,
+		OneHourResetAt:  cache.OneHourResetAt,
+		HasOneHour:      cache.HasOneHour,
 		APIUnavailable:  cache.APIUnavailable,
 		APIError:        cache.APIError,
 		Provider:        cache.Provider,
@@ -1,6 +1,7 @@
 package content
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -9,6 +10,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -263,14 +265,20 @@ func TestApplyProxyToTransport(t *testing.T) {
 // TestNewClaudeHTTPClient_AppliesConfiguredProxy verifies the public entrypoint
 // pulls from the package-level proxy state.
 func TestNewClaudeHTTPClient_AppliesConfiguredProxy(t *testing.T) {
-	t.Run("no proxy configured → direct (no Proxy / no DialContext)", func(t *testing.T) {
+	t.Run("no proxy configured and no env proxy → direct", func(t *testing.T) {
 		SetClaudeAPIProxy("")
 		t.Cleanup(func() { SetClaudeAPIProxy("") })
+		clearProxyEnv(t)
 
 		client := newClaudeHTTPClient(5 * time.Second)
 		tr, ok := client.Transport.(*http.Transport)
 		require.True(t, ok)
-		assert.Nil(t, tr.Proxy)
+		require.NotNil(t, tr.Proxy, "env proxies are consulted when nothing is configured")
+		req, err := http.NewRequest("GET", usageAPIURL, nil)
+		require.NoError(t, err)
+		resolved, err := tr.Proxy(req)
+		require.NoError(t, err)
+		assert.Nil(t, resolved)
 		assert.Nil(t, tr.DialContext)
 	})
 
@@ -296,6 +304,94 @@ func TestNewClaudeHTTPClient_AppliesConfiguredProxy(t *testing.T) {
 	})
 }
 
+// clearProxyEnv unsets every proxy variable httpproxy.FromEnvironment reads.
+func clearProxyEnv(t *testing.T) {
+	t.Helper()
+	for _, k := range []string{"HTTP_PROXY", "http_proxy", "HTTPS_PROXY", "https_proxy", "NO_PROXY", "no_proxy", "REQUEST_METHOD"} {
+		t.Setenv(k, "")
+	}
+}
+
+// TestNewClaudeHTTPClient_EnvProxy exercises real requests against httptest
+// servers: direct, through HTTP_PROXY, through an unreachable HTTP_PROXY,
+// and with the configured proxy overriding the environment.
+func TestNewClaudeHTTPClient_EnvProxy(t *testing.T) {
+	// The proxy answers absolute-form requests for the (unresolvable) target.
+	var proxied atomic.Int32
+	proxyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Host == "api.anthropic.invalid" {
+			proxied.Add(1)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxyServer.Close()
+	const target = "http://api.anthropic.invalid/api/oauth/usage"
+
+	// A listener that is closed straight away gives a port nothing listens on.
+	dead := httptest.NewServer(http.NotFoundHandler())
+	deadURL := dead.URL
+	dead.Close()
+
+	get := func(t *testing.T, rawURL string) error {
+		t.Helper()
+		resp, err := newClaudeHTTPClient(2 * time.Second).Get(rawURL)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		return nil
+	}
+
+	t.Run("direct", func(t *testing.T) {
+		// Arrange
+		SetClaudeAPIProxy("")
+		clearProxyEnv(t)
+		direct := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer direct.Close()
+
+		// Act / Assert
+		require.NoError(t, get(t, direct.URL))
+	})
+
+	t.Run("proxied via HTTP_PROXY", func(t *testing.T) {
+		// Arrange
+		SetClaudeAPIProxy("")
+		clearProxyEnv(t)
+		t.Setenv("HTTP_PROXY", proxyServer.URL)
+		before := proxied.Load()
+
+		// Act / Assert
+		require.NoError(t, get(t, target))
+		assert.Equal(t, before+1, proxied.Load())
+	})
+
+	t.Run("unreachable HTTP_PROXY fails", func(t *testing.T) {
+		// Arrange
+		SetClaudeAPIProxy("")
+		clearProxyEnv(t)
+		t.Setenv("HTTP_PROXY", deadURL)
+
+		// Act / Assert
+		assert.Error(t, get(t, target))
+	})
+
+	t.Run("configured proxy overrides HTTP_PROXY", func(t *testing.T) {
+		// Arrange
+		clearProxyEnv(t)
+		t.Setenv("HTTP_PROXY", deadURL)
+		SetClaudeAPIProxy(proxyServer.URL)
+		t.Cleanup(func() { SetClaudeAPIProxy("") })
+		before := proxied.Load()
+
+		// Act / Assert
+		require.NoError(t, get(t, target))
+		assert.Equal(t, before+1, proxied.Load())
+	})
+}
+
 // ---------------------------------------------------------------------------
 // getCachePath
 // ---------------------------------------------------------------------------
@@ -713,6 +809,96 @@ func TestWriteRefreshFailedCache_NetworkError(t *testing.T) {
 	assert.Equal(t, 0, got.RateLimitedCount, "RateLimitedCount should reset on non-rate-limit error")
 }
 
+func TestWriteRefreshFailedCache_NetworkError_CountsConsecutiveFailures(t *testing.T) {
+	// Arrange: two earlier network failures already recorded
+	setupTempHomeDir(t)
+	oldCache := &usageCacheData{FiveHour: 50.0, APIError: "network", NetworkFailureCount: 2}
+
+	// Act
+	err := writeRefreshFailedCache(oldCache, false, 0, "", "")
+
+	// Assert
+	require.NoError(t, err)
+	got := readUsageCache("anthropic", "")
+	require.NotNil(t, got)
+	assert.Equal(t, 3, got.NetworkFailureCount)
+}
+
+func TestWriteRefreshFailedCache_RateLimit_ResetsNetworkFailureCount(t *testing.T) {
+	// Arrange
+	setupTempHomeDir(t)
+	oldCache := &usageCacheData{FiveHour: 50.0, APIError: "network", NetworkFailureCount: 4}
+
+	// Act
+	err := writeRefreshFailedCache(oldCache, true, 0, "", "")
+
+	// Assert
+	require.NoError(t, err)
+	got := readUsageCache("anthropic", "")
+	require.NotNil(t, got)
+	assert.Equal(t, 0, got.NetworkFailureCount)
+}
+
+func TestWriteRefreshedCache_ResetsNetworkFailureCount(t *testing.T) {
+	// Arrange
+	setupTempHomeDir(t)
+	oldCache := &usageCacheData{APIError: "network", NetworkFailureCount: 4}
+
+	// Act
+	err := writeRefreshedCache(&UsageData{FiveHour: 10}, oldCache)
+
+	// Assert
+	require.NoError(t, err)
+	got := readUsageCache("anthropic", "")
+	require.NotNil(t, got)
+	assert.Equal(t, 0, got.NetworkFailureCount)
+}
+
+func TestGetNetworkFailureTTL(t *testing.T) {
+	tests := []struct {
+		count int
+		want  time.Duration
+	}{
+		{0, 15 * time.Second},
+		{1, 15 * time.Second},
+		{2, 30 * time.Second},
+		{3, time.Minute},
+		{7, 16 * time.Minute},
+		{8, 30 * time.Minute},
+		{50, 30 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("count=%d", tt.count), func(t *testing.T) {
+			// Act
+			got := getNetworkFailureTTL(tt.count)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestShouldRefreshResult_NetworkFailureBackoff(t *testing.T) {
+	// Arrange: 5 consecutive network failures → 240s TTL; written 2 min ago
+	homeDir := setupTempHomeDir(t)
+	c := &usageCacheData{
+		FetchedAt:           time.Now().Add(-2 * time.Minute),
+		APIUnavailable:      true,
+		APIError:            "network",
+		NetworkFailureCount: 5,
+	}
+	writeTestCacheFile(t, homeDir, c)
+
+	// Act
+	shouldRefresh, cache, isBackoff := shouldRefreshResult("anthropic", "")
+
+	// Assert: still within the stretched failure TTL, no new request
+	assert.False(t, shouldRefresh)
+	require.NotNil(t, cache)
+	assert.False(t, isBackoff)
+}
+
 func TestWriteRefreshFailedCache_NilOldCache_RateLimit(t *testing.T) {
 	// Arrange: nil old cache — first-ever failure, rate-limited
 	setupTempHomeDir(t)
@@ -792,6 +978,58 @@ func TestFetchUsageAPI_Success(t *testing.T) {
 	assert.InDelta(t, 20.0, usage.SevenDay, 0.001)
 }
 
+func TestFetchUsageAPI_Success_OneHourWindow(t *testing.T) {
+	// Arrange
+	setupTestAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+			"one_hour": {"utilization": 64.0, "resets_at": "2026-03-17T10:23:00Z"},
+			"five_hour": {"utilization": 22.0, "resets_at": "2026-03-17T14:32:00Z"},
+			"seven_day": {"utilization": 2.0, "resets_at": "2026-03-18T22:00:00Z"}
+		}`))
+	})
+
+	// Act
+	usage, _, _, err := fetchUsageAPI("tok")
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, usage)
+	assert.True(t, usage.HasOneHour)
+	assert.InDelta(t, 64.0, usage.OneHour, 0.001)
+	assert.Equal(t, time.Date(2026, 3, 17, 10, 23, 0, 0, time.UTC), usage.OneHourResetAt.UTC())
+
+	// The 1h window leads the rendered line as the tightest limit.
+	fixedNow := time.Date(2026, 3, 17, 10, 0, 0, 0, time.UTC)
+	oldNow := nowFn
+	nowFn = func() time.Time { return fixedNow }
+	t.Cleanup(func() { nowFn = oldNow })
+	mockSubscriptionUsage(t, func() *UsageData { return usage })
+
+	result := stripANSI(getSubscriptionQuota(&StatusLineInput{}))
+	assert.Equal(t, "📊 64% 1h ↻ 23m · 22% 5h ↻ 4h32m · 2% 7d ↻ 1d12h", result)
+}
+
+func TestFetchUsageAPI_Success_NoOneHourWindow(t *testing.T) {
+	// Arrange
+	setupTestAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"five_hour": {"utilization": 22.0}, "seven_day": {"utilization": 2.0}}`))
+	})
+
+	// Act
+	usage, _, _, err := fetchUsageAPI("tok")
+	require.NoError(t, err)
+	mockSubscriptionUsage(t, func() *UsageData { return usage })
+	result := getSubscriptionQuota(&StatusLineInput{})
+
+	// Assert
+	assert.False(t, usage.HasOneHour)
+	assert.NotContains(t, result, "1h")
+}
+
 func TestFetchUsageAPI_Success_ParsesResetAt(t *testing.T) {
 	// Arrange
 	resetAt := "2026-03-17T15:30:00Z"
@@ -878,6 +1116,113 @@ func TestFetchUsageAPI_InvalidJSON(t *testing.T) {
 	assert.Nil(t, usage)
 }
 
+func TestFetchUsageAPI_RetriesOnceAfterServerError(t *testing.T) {
+	// Arrange: first call 503, second call succeeds
+	var calls atomic.Int32
+	setupTestAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"five_hour":{"utilization":12}}`))
+	})
+
+	// Act
+	usage, isRateLimited, _, err := fetchUsageAPI("tok")
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, usage)
+	assert.False(t, isRateLimited)
+	assert.Equal(t, 12.0, usage.FiveHour)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestFetchUsageAPI_RetryCounts(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		retryAfter string
+		wantCalls  int32
+	}{
+		{"persistent 500 retried once", http.StatusInternalServerError, "", 2},
+		{"429 without Retry-After retried once", http.StatusTooManyRequests, "", 2},
+		{"429 with long Retry-After not retried", http.StatusTooManyRequests, "90", 1},
+		{"4xx not retried", http.StatusUnauthorized, "", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var calls atomic.Int32
+			setupTestAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.status)
+			})
+
+			// Act
+			usage, _, _, err := fetchUsageAPI("tok")
+
+			// Assert
+			assert.Error(t, err)
+			assert.Nil(t, usage)
+			assert.Equal(t, tt.wantCalls, calls.Load())
+		})
+	}
+}
+
+func TestRetryOnce_ContextExpiredDuringWait(t *testing.T) {
+	// Arrange: the delay outlasts the context, so the retry never happens
+	old := usageRetryDelay
+	usageRetryDelay = time.Minute
+	t.Cleanup(func() { usageRetryDelay = old })
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls := 0
+	do := func() (*http.Response, error) {
+		calls++
+		return &http.Response{StatusCode: http.StatusBadGateway, Header: http.Header{}, Body: http.NoBody}, nil
+	}
+
+	// Act
+	resp, err := retryOnce(ctx, do)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetryOnce_LogsOnlyInDebugMode(t *testing.T) {
+	do := func() (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}, Body: http.NoBody}, nil
+	}
+	for _, debug := range []string{"", "1"} {
+		t.Run("STATUSLINE_DEBUG="+debug, func(t *testing.T) {
+			// Arrange
+			t.Setenv("STATUSLINE_DEBUG", debug)
+			var log strings.Builder
+			old := retryLog
+			retryLog = &log
+			t.Cleanup(func() { retryLog = old })
+
+			// Act
+			_, err := retryOnce(context.Background(), do)
+
+			// Assert
+			require.NoError(t, err)
+			if debug == "1" {
+				assert.Contains(t, log.String(), "usage API returned 503, retrying")
+			} else {
+				assert.Empty(t, log.String())
+			}
+		})
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Collector interface
 // ---------------------------------------------------------------------------
@@ -1776,6 +2121,9 @@ func TestGetLocalTimeZoneName_EmptyTZ_NoEtcLocaltime(t *testing.T) {
 	defer os.Setenv("TZ", originalTZ)
 	oldReadlink := readlinkFn
 	defer func() { readlinkFn = oldReadlink }()
+	oldPlatformZone := platformZoneFn
+	defer func() { platformZoneFn = oldPlatformZone }()
+	platformZoneFn = func() string { return "" }
 
 	os.Setenv("TZ", "")
 	readlinkFn = func(name string) (string, error) {
@@ -1794,6 +2142,9 @@ func TestGetLocalTimeZoneName_NonZeroOffsetMinutes(t *testing.T) {
 	defer os.Setenv("TZ", originalTZ)
 	oldReadlink := readlinkFn
 	defer func() { readlinkFn = oldReadlink }()
+	oldPlatformZone := platformZoneFn
+	defer func() { platformZoneFn = oldPlatformZone }()
+	platformZoneFn = func() string { return "" }
 
 	os.Setenv("TZ", "")
 	readlinkFn = func(name string) (string, error) {
@@ -1997,6 +2348,10 @@ func TestGetLocalTimeZoneName_UTC(t *testing.T) {
 	readlinkFn = func(name string) (string, error) { return "", fmt.Errorf("not found") }
 	defer func() { readlinkFn = oldRL }()
 
+	oldPZ := platformZoneFn
+	platformZoneFn = func() string { return "" }
+	defer func() { platformZoneFn = oldPZ }()
+
 	oldTZ := timeZoneFn
 	timeZoneFn = func() (string, int) { return "UTC", 0 }
 	defer func() { timeZoneFn = oldTZ }()
@@ -2018,6 +2373,10 @@ func TestGetLocalTimeZoneName_NegativeOffset(t *testing.T) {
 	readlinkFn = func(name string) (string, error) { return "", fmt.Errorf("not found") }
 	defer func() { readlinkFn = oldRL }()
 
+	oldPZ := platformZoneFn
+	platformZoneFn = func() string { return "" }
+	defer func() { platformZoneFn = oldPZ }()
+
 	oldTZ := timeZoneFn
 	timeZoneFn = func() (string, int) { return "EST", -18000 }
 	defer func() { timeZoneFn = oldTZ }()
@@ -2039,6 +2398,10 @@ func TestGetLocalTimeZoneName_OffsetWithMinutes(t *testing.T) {
 	readlinkFn = func(name string) (string, error) { return "", fmt.Errorf("not found") }
 	defer func() { readlinkFn = oldRL }()
 
+	oldPZ := platformZoneFn
+	platformZoneFn = func() string { return "" }
+	defer func() { platformZoneFn = oldPZ }()
+
 	oldTZ := timeZoneFn
 	timeZoneFn = func() (string, int) { return "IST", 19800 }
 	defer func() { timeZoneFn = oldTZ }()
@@ -2060,6 +2423,10 @@ func TestGetLocalTimeZoneName_NegativeOffsetWithMinutes(t *testing.T) {
 	readlinkFn = func(name string) (string, error) { return "", fmt.Errorf("not found") }
 	defer func() { readlinkFn = oldRL }()
 
+	oldPZ := platformZoneFn
+	platformZoneFn = func() string { return "" }
+	defer func() { platformZoneFn = oldPZ }()
+
 	oldTZ := timeZoneFn
 	timeZoneFn = func() (string, int) { return "MART", -34200 }
 	defer func() { timeZoneFn = oldTZ }()
@@ -2168,3 +2535,43 @@ func TestSyncFile_NilFn(t *testing.T) {
 	require.NotNil(t, got)
 	assert.InDelta(t, 2.0, got.FiveHour, 0.001)
 }
+
+// ---------------------------------------------------------------------------
+// QuotaDualCollector
+// ---------------------------------------------------------------------------
+
+func TestQuotaDualCollector_Collect(t *testing.T) {
+	reset := time.Date(2026, 3, 17, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		usage *UsageData
+		want  string
+	}{
+		{"both windows", &UsageData{Provider: "anthropic", FiveHour: 42, SevenDay: 18}, "📊 5h 42% · 7d 18%"},
+		{"only 5h present", &UsageData{Provider: "glm-zai", FiveHour: 42, FiveHourResetAt: reset}, "📊 5h 42%"},
+		{"only 7d present", &UsageData{Provider: "glm-zai", SevenDay: 18}, "📊 7d 18%"},
+		{"anthropic with no usage keeps both", &UsageData{Provider: "anthropic"}, "📊 5h 0% · 7d 0%"},
+		{"non-anthropic with nothing is empty", &UsageData{Provider: "glm-zai"}, ""},
+		{"no usage data", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockSubscriptionUsage(t, func() *UsageData { return tt.usage })
+
+			// Act
+			got, err := NewQuotaDualCollector().Collect(&StatusLineInput{}, nil)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, stripANSI(got))
+		})
+	}
+}
+
+func TestQuotaDualCollector_Collect_InvalidInput(t *testing.T) {
+	_, err := NewQuotaDualCollector().Collect("not a StatusLineInput", nil)
+	require.Error(t, err)
+}
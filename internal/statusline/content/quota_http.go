@@ -1,13 +1,18 @@
 package content
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"os"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"golang.org/x/net/http/httpproxy"
 	"golang.org/x/net/proxy"
 )
 
@@ -17,6 +22,18 @@ import (
 // and the only visible effect is "looks like the API failed" in the cache.
 const httpTimeoutSeconds = 4
 
+// usageAPIBudget bounds a usage API fetch including its one retry. It matches
+// httpTimeoutSeconds for the same reason: the quota collector gives up after
+// 4s, so a retry only happens when the first attempt failed fast.
+const usageAPIBudget = httpTimeoutSeconds * time.Second
+
+// usageRetryDelay is the pause before retrying a 429 or 5xx response. A var
+// so TestMain can zero it.
+var usageRetryDelay = time.Second
+
+// retryLog receives retry notices when STATUSLINE_DEBUG=1. Override in tests.
+var retryLog io.Writer = os.Stderr
+
 // claudeAPIProxy holds the proxy URL applied only to api.anthropic.com requests.
 // Empty (default) → no proxy. Precedence resolution (CLI > env > YAML) happens
 // in (*config.Config).ResolveClaudeAPIProxy and is passed in via SetClaudeAPIProxy.
@@ -45,9 +62,9 @@ func getClaudeAPIProxy() string {
 }
 
 // newClaudeHTTPClient returns an HTTP client for Claude OAuth API requests.
-// When a proxy is configured it routes through that proxy; otherwise it uses
-// a direct connection. It deliberately does NOT honor HTTP_PROXY/HTTPS_PROXY
-// so unrelated environment proxies cannot leak into Claude API traffic.
+// An explicitly configured proxy wins; otherwise HTTP_PROXY / HTTPS_PROXY /
+// NO_PROXY apply, read afresh on each call rather than through
+// http.ProxyFromEnvironment's once-per-process cache.
 //
 // Supported schemes:
 //   - http://  / https:// — standard HTTP CONNECT proxy. Basic-auth credentials
@@ -62,6 +79,11 @@ func newClaudeHTTPClient(timeout time.Duration) *http.Client {
 	transport := &http.Transport{Proxy: nil}
 	if raw := getClaudeAPIProxy(); raw != "" {
 		applyProxyToTransport(transport, raw)
+	} else {
+		envProxy := httpproxy.FromEnvironment().ProxyFunc()
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			return envProxy(req.URL)
+		}
 	}
 	return &http.Client{Timeout: timeout, Transport: transport}
 }
@@ -119,3 +141,40 @@ func parseRetryAfterHeader(value string) int {
 
 	return 0
 }
+
+// retryOnce performs do and, on a 429 or 5xx response, waits usageRetryDelay
+// and performs it once more, so a brief API blip doesn't get cached as a
+// failure. A 429 whose Retry-After asks for longer than the delay is returned
+// as is; the rate-limit backoff honours it instead. If ctx expires during the
+// wait, the first response is returned.
+func retryOnce(ctx context.Context, do func() (*http.Response, error)) (*http.Response, error) {
+	resp, err := do()
+	if err != nil || !shouldRetryStatus(resp) {
+		return resp, err
+	}
+
+	if os.Getenv("STATUSLINE_DEBUG") == "1" {
+		fmt.Fprintf(retryLog, "statusline: usage API returned %d, retrying in %s\n", resp.StatusCode, usageRetryDelay)
+	}
+	timer := time.NewTimer(usageRetryDelay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return resp, nil
+	case <-timer.C:
+	}
+	resp.Body.Close()
+	return do()
+}
+
+// shouldRetryStatus reports whether resp is worth one retry: any 5xx, or a
+// 429 without a Retry-After longer than usageRetryDelay.
+func shouldRetryStatus(resp *http.Response) bool {
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return time.Duration(parseRetryAfterHeader(resp.Header.Get("Retry-After")))*time.Second <= usageRetryDelay
+	case resp.StatusCode >= 500 && resp.StatusCode <= 599:
+		return true
+	}
+	return false
+}
@@ -163,7 +163,7 @@ func TestGetAnthropicUsage_NilInputStillUsesAPI(t *testing.T) {
 	var hits int32
 	setupTestAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
 		atomic.AddInt32(&hits, 1)
-		w.WriteHeader(http.StatusInternalServerError)
+		w.WriteHeader(http.StatusUnauthorized) // not retried, so one hit per fetch
 	})
 
 	homeDir := setupTempHomeDir(t)
@@ -4,7 +4,11 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/young1lin/claude-token-monitor/internal/textutil"
+	"github.com/young1lin/claude-token-monitor/internal/timeutil"
 )
 
 // AgentCollector collects agent information
@@ -31,12 +35,7 @@ func (c *AgentCollector) Collect(input interface{}, summary interface{}) (string
 	agent := transcriptSummary.Agents[len(transcriptSummary.Agents)-1]
 	agentInfo := agent.Type
 	if agent.Desc != "" {
-		desc := agent.Desc
-		runes := []rune(desc)
-		if len(runes) > 20 {
-			desc = string(runes[:17]) + ".."
-		}
-		agentInfo = fmt.Sprintf("%s: %s", agentInfo, desc)
+		agentInfo = fmt.Sprintf("%s: %s", agentInfo, textutil.Truncate(agent.Desc, 20))
 	}
 	return fmt.Sprintf("🤖 %s", agentInfo), nil
 }
@@ -80,19 +79,27 @@ func NewToolsCollector() *ToolsCollector {
 	}
 }
 
-// Collect returns tool usage statistics
+// Collect returns tool usage statistics, "🔧 12 tools", with the failed share
+// appended when any call errored: "🔧 12 tools (2 failed)".
 func (c *ToolsCollector) Collect(input interface{}, summary interface{}) (string, error) {
 	transcriptSummary, ok := summary.(*TranscriptSummary)
 	if !ok {
 		return "", fmt.Errorf("invalid summary type")
 	}
-	if len(transcriptSummary.CompletedTools) == 0 {
-		return "", nil
-	}
-	total := 0
+	total, failed := 0, 0
 	for _, count := range transcriptSummary.CompletedTools {
 		total += count
 	}
+	for _, count := range transcriptSummary.FailedTools {
+		failed += count
+	}
+	total += failed
+	if total == 0 {
+		return "", nil
+	}
+	if failed > 0 {
+		return fmt.Sprintf("🔧 %d tools (%d failed)", total, failed), nil
+	}
 	return fmt.Sprintf("🔧 %d tools", total), nil
 }
 
@@ -126,6 +133,155 @@ func (c *SessionDurationCollector) Collect(input interface{}, summary interface{
 	return fmt.Sprintf("⏱️ %s", formatDuration(duration)), nil
 }
 
+// showDataAge gates the data-age debug element. Off by default; main enables
+// it via SetShowDataAge when STATUSLINE_DEBUG=1 or display.showDataAge is set.
+var (
+	showDataAge   bool
+	showDataAgeMu sync.RWMutex
+)
+
+// SetShowDataAge enables or disables the data-age element. Thread-safe.
+func SetShowDataAge(enabled bool) {
+	showDataAgeMu.Lock()
+	defer showDataAgeMu.Unlock()
+	showDataAge = enabled
+}
+
+// getShowDataAge returns whether the data-age element is enabled.
+func getShowDataAge() bool {
+	showDataAgeMu.RLock()
+	defer showDataAgeMu.RUnlock()
+	return showDataAge
+}
+
+// DataAgeCollector reports how old the rendered data is, i.e. the gap between
+// this statusline run and the newest transcript timestamp. Used to tell a
+// stale re-render apart from Claude Code not re-invoking the statusline.
+type DataAgeCollector struct {
+	*BaseCollector
+}
+
+// NewDataAgeCollector creates a new data age collector
+func NewDataAgeCollector() *DataAgeCollector {
+	return &DataAgeCollector{
+		BaseCollector: NewBaseCollector(ContentDataAge, time.Second, true),
+	}
+}
+
+// Collect returns the data age, e.g. "(2s ago)". Empty unless enabled.
+func (c *DataAgeCollector) Collect(input interface{}, summary interface{}) (string, error) {
+	transcriptSummary, ok := summary.(*TranscriptSummary)
+	if !ok {
+		return "", fmt.Errorf("invalid summary type")
+	}
+	if !getShowDataAge() || transcriptSummary.SessionEnd.IsZero() {
+		return "", nil
+	}
+	age := nowFn().Sub(transcriptSummary.SessionEnd)
+	if age < 0 {
+		age = 0
+	}
+	return fmt.Sprintf("(%s ago)", formatDuration(age)), nil
+}
+
+// turnLimit is the soft per-session turn limit from format.turnLimit. 0 (the
+// default) hides the turns element; set via SetTurnLimit from main.
+var (
+	turnLimit   int
+	turnLimitMu sync.RWMutex
+)
+
+// SetTurnLimit sets the soft turn limit the turns element compares against.
+// Thread-safe.
+func SetTurnLimit(limit int) {
+	turnLimitMu.Lock()
+	defer turnLimitMu.Unlock()
+	turnLimit = limit
+}
+
+// getTurnLimit returns the configured soft turn limit.
+func getTurnLimit() int {
+	turnLimitMu.RLock()
+	defer turnLimitMu.RUnlock()
+	return turnLimit
+}
+
+// TurnsCollector shows how many user turns the session has used against the
+// configured soft limit, to help pace long conversations.
+type TurnsCollector struct {
+	*BaseCollector
+}
+
+// NewTurnsCollector creates a new turns collector
+func NewTurnsCollector() *TurnsCollector {
+	return &TurnsCollector{
+		BaseCollector: NewBaseCollector(ContentTurns, 5*time.Second, true),
+	}
+}
+
+// Collect returns "💬 18/50", coloured with the quota tiers as the count
+// climbs toward the limit. Empty when no limit is configured.
+func (c *TurnsCollector) Collect(input interface{}, summary interface{}) (string, error) {
+	transcriptSummary, ok := summary.(*TranscriptSummary)
+	if !ok {
+		return "", fmt.Errorf("invalid summary type")
+	}
+	limit := getTurnLimit()
+	if limit <= 0 {
+		return "", nil
+	}
+	pct := float64(transcriptSummary.UserTurns) / float64(limit) * 100
+	return fmt.Sprintf("💬 %s%d/%d\x1b[0m", quotaPercentColor(pct), transcriptSummary.UserTurns, limit), nil
+}
+
+// showApproval gates the approval element. Off by default; main enables it
+// via SetShowApproval when display.showApproval is set.
+var (
+	showApproval   bool
+	showApprovalMu sync.RWMutex
+)
+
+// SetShowApproval enables or disables the approval element. Thread-safe.
+func SetShowApproval(enabled bool) {
+	showApprovalMu.Lock()
+	defer showApprovalMu.Unlock()
+	showApproval = enabled
+}
+
+// getShowApproval returns whether the approval element is enabled.
+func getShowApproval() bool {
+	showApprovalMu.RLock()
+	defer showApprovalMu.RUnlock()
+	return showApproval
+}
+
+// ApprovalCollector flags a tool call that is blocked on the user's
+// permission, so "Claude is waiting on me" is distinguishable from "Claude is
+// thinking".
+type ApprovalCollector struct {
+	*BaseCollector
+}
+
+// NewApprovalCollector creates a new pending-approval collector
+func NewApprovalCollector() *ApprovalCollector {
+	return &ApprovalCollector{
+		BaseCollector: NewBaseCollector(ContentApproval, time.Second, true),
+	}
+}
+
+// Collect returns "⏸ awaiting approval: Bash" in bold yellow, or empty when
+// nothing is pending. Empty unless enabled.
+func (c *ApprovalCollector) Collect(input interface{}, summary interface{}) (string, error) {
+	transcriptSummary, ok := summary.(*TranscriptSummary)
+	if !ok {
+		return "", fmt.Errorf("invalid summary type")
+	}
+	if !getShowApproval() || transcriptSummary.AwaitingApproval == "" {
+		return "", nil
+	}
+	return fmt.Sprintf("\x1b[1;33m⏸ awaiting approval: %s\x1b[0m", transcriptSummary.AwaitingApproval), nil
+}
+
 // ToolStatusDetailCollector collects per-tool success/failure breakdown
 type ToolStatusDetailCollector struct {
 	*BaseCollector
@@ -194,15 +350,40 @@ func (c *ToolStatusDetailCollector) Collect(input interface{}, summary interface
 	return strings.Join(parts, " "), nil
 }
 
-// formatDuration formats a duration as a human-readable string
-func formatDuration(d time.Duration) string {
-	if d < time.Minute {
-		return fmt.Sprintf("%ds", int(d.Seconds()))
-	} else if d < time.Hour {
-		return fmt.Sprintf("%dm", int(d.Minutes()))
-	} else {
-		h := int(d.Hours())
-		m := int(d.Minutes()) % 60
-		return fmt.Sprintf("%dh%dm", h, m)
+// durationCapDays caps how many days formatDuration spells out; past it the
+// duration collapses to "<cap>d+". 0 (default) means no cap. Set via
+// SetDurationCapDays from main once the YAML config is loaded.
+var (
+	durationCapDays   int
+	durationCapDaysMu sync.RWMutex
+)
+
+// SetDurationCapDays configures the day cap for durations. Non-positive
+// values disable the cap. Thread-safe.
+func SetDurationCapDays(days int) {
+	if days < 0 {
+		days = 0
 	}
+	durationCapDaysMu.Lock()
+	defer durationCapDaysMu.Unlock()
+	durationCapDays = days
+}
+
+// getDurationCapDays returns the configured day cap (0 = none).
+func getDurationCapDays() int {
+	durationCapDaysMu.RLock()
+	defer durationCapDaysMu.RUnlock()
+	return durationCapDays
+}
+
+// formatDuration formats a duration as a human-readable string, honouring
+// the configured day cap (see timeutil.FormatDuration).
+func formatDuration(d time.Duration) string {
+	return timeutil.FormatDuration(d, getDurationCapDays())
+}
+
+// FormatDuration formats a duration the way the session-duration segment
+// shows it, honouring the configured day cap, e.g. "1h30m".
+func FormatDuration(d time.Duration) string {
+	return timeutil.FormatDuration(d, getDurationCapDays())
 }
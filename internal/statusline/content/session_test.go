@@ -225,6 +225,23 @@ func TestToolsCollector_Collect(t *testing.T) {
 			want:    "\U0001f527 20 tools",
 			wantErr: false,
 		},
+		{
+			name: "failures counted and called out",
+			summary: &TranscriptSummary{
+				CompletedTools: map[string]int{"Read": 8, "Bash": 2},
+				FailedTools:    map[string]int{"Bash": 1, "WebFetch": 1},
+			},
+			want:    "\U0001f527 12 tools (2 failed)",
+			wantErr: false,
+		},
+		{
+			name: "only failures",
+			summary: &TranscriptSummary{
+				FailedTools: map[string]int{"Edit": 1},
+			},
+			want:    "\U0001f527 1 tools (1 failed)",
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -463,6 +480,7 @@ func TestFormatDuration(t *testing.T) {
 		want string
 	}{
 		{name: "zero duration", d: 0, want: "0s"},
+		{name: "sub-second rounds to 1s", d: 700 * time.Millisecond, want: "1s"},
 		{name: "30 seconds", d: 30 * time.Second, want: "30s"},
 		{name: "59 seconds", d: 59 * time.Second, want: "59s"},
 		{name: "1 minute", d: 1 * time.Minute, want: "1m"},
@@ -472,10 +490,42 @@ func TestFormatDuration(t *testing.T) {
 		{name: "1 hour 30 minutes", d: 1*time.Hour + 30*time.Minute, want: "1h30m"},
 		{name: "3 hours 45 minutes", d: 3*time.Hour + 45*time.Minute, want: "3h45m"},
 		{name: "24 hours", d: 24 * time.Hour, want: "24h0m"},
+		{name: "25 hours switches to days", d: 25 * time.Hour, want: "1d 1h"},
+		{name: "3 days 4 hours", d: 76*time.Hour + 30*time.Minute, want: "3d 4h"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := formatDuration(tt.d)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFormatDuration_DayCap(t *testing.T) {
+	t.Cleanup(func() { SetDurationCapDays(0) })
+
+	tests := []struct {
+		name string
+		cap  int
+		d    time.Duration
+		want string
+	}{
+		{name: "under cap shows days", cap: 2, d: 25 * time.Hour, want: "1d 1h"},
+		{name: "exactly at cap", cap: 2, d: 48 * time.Hour, want: "2d 0h"},
+		{name: "past cap collapses", cap: 2, d: 49 * time.Hour, want: "2d+"},
+		{name: "cap does not affect hours", cap: 1, d: 5 * time.Hour, want: "5h0m"},
+		{name: "negative cap disables", cap: -1, d: 200 * time.Hour, want: "8d 8h"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			SetDurationCapDays(tt.cap)
+
 			// Act
 			got := formatDuration(tt.d)
 
@@ -550,3 +600,132 @@ func TestToolStatusDetailCollector_MixedWithANSICodes(t *testing.T) {
 	assert.Contains(t, got, "\x1b[1;32m") // green for success
 	assert.Contains(t, got, "\x1b[1;31m") // red for failure
 }
+
+func TestDataAgeCollector_Collect(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	old := nowFn
+	nowFn = func() time.Time { return now }
+	t.Cleanup(func() {
+		nowFn = old
+		SetShowDataAge(false)
+	})
+	collector := NewDataAgeCollector()
+
+	tests := []struct {
+		name    string
+		enabled bool
+		summary *TranscriptSummary
+		want    string
+	}{
+		{"disabled returns empty", false, &TranscriptSummary{SessionEnd: now.Add(-2 * time.Second)}, ""},
+		{"no transcript timestamp returns empty", true, &TranscriptSummary{}, ""},
+		{"seconds", true, &TranscriptSummary{SessionEnd: now.Add(-2 * time.Second)}, "(2s ago)"},
+		{"minutes", true, &TranscriptSummary{SessionEnd: now.Add(-5 * time.Minute)}, "(5m ago)"},
+		{"future timestamp clamps to zero", true, &TranscriptSummary{SessionEnd: now.Add(time.Second)}, "(0s ago)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			SetShowDataAge(tt.enabled)
+
+			// Act
+			got, err := collector.Collect(nil, tt.summary)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDataAgeCollector_Collect_InvalidSummary(t *testing.T) {
+	collector := NewDataAgeCollector()
+
+	// Act
+	_, err := collector.Collect(nil, "invalid")
+
+	// Assert
+	require.Error(t, err)
+}
+
+func TestApprovalCollector_Collect(t *testing.T) {
+	t.Cleanup(func() { SetShowApproval(false) })
+	collector := NewApprovalCollector()
+
+	tests := []struct {
+		name    string
+		enabled bool
+		summary *TranscriptSummary
+		want    string
+	}{
+		{"disabled returns empty", false, &TranscriptSummary{AwaitingApproval: "Bash"}, ""},
+		{"nothing pending", true, &TranscriptSummary{}, ""},
+		{"pending Bash", true, &TranscriptSummary{AwaitingApproval: "Bash"}, "\x1b[1;33m⏸ awaiting approval: Bash\x1b[0m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			SetShowApproval(tt.enabled)
+
+			// Act
+			got, err := collector.Collect(nil, tt.summary)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestApprovalCollector_Collect_InvalidSummary(t *testing.T) {
+	collector := NewApprovalCollector()
+
+	// Act
+	_, err := collector.Collect(nil, "invalid")
+
+	// Assert
+	require.Error(t, err)
+}
+
+func TestTurnsCollector_Collect(t *testing.T) {
+	t.Cleanup(func() { SetTurnLimit(0) })
+	collector := NewTurnsCollector()
+
+	tests := []struct {
+		name  string
+		limit int
+		turns int
+		want  string
+	}{
+		{"no limit hides", 0, 18, ""},
+		{"low usage", 50, 5, "💬 \x1b[1;92m5/50\x1b[0m"},
+		{"climbing", 50, 32, "💬 \x1b[1;33m32/50\x1b[0m"},
+		{"past the limit", 50, 55, "💬 \x1b[1;31m55/50\x1b[0m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			SetTurnLimit(tt.limit)
+
+			// Act
+			got, err := collector.Collect(nil, &TranscriptSummary{UserTurns: tt.turns})
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTurnsCollector_Collect_InvalidSummary(t *testing.T) {
+	collector := NewTurnsCollector()
+
+	// Act
+	_, err := collector.Collect(nil, "invalid")
+
+	// Assert
+	require.Error(t, err)
+}
@@ -20,9 +20,11 @@ import (
 //     hit this path and would otherwise add ~300ms of wall-clock time.
 //     The single test that exercises the real coordination semantics
 //     (TestShouldRefreshResult_RefreshMarkingWriteFail) restores 50ms via
-//     t.Cleanup for its own duration.
+//     t.Cleanup for its own duration. usageRetryDelay is zeroed for the
+//     same reason: 429/5xx fetch tests would each wait a second to retry.
 func TestMain(m *testing.M) {
 	os.Unsetenv("CLAUDE_CONFIG_DIR")
 	refreshCoordDelay = 0
+	usageRetryDelay = 0
 	os.Exit(m.Run())
 }
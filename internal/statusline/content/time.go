@@ -4,14 +4,16 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 	"time"
 )
 
 // Test injection points for the timezone-name resolution path. Kept here
 // (not in quota_*) so reviewers see "tz logic and its overrides together".
 var (
-	readlinkFn = os.Readlink                                       // Override os.Readlink in tests
-	timeZoneFn = func() (string, int) { return time.Now().Zone() } // Override in tests
+	readlinkFn     = os.Readlink                                       // Override os.Readlink in tests
+	timeZoneFn     = func() (string, int) { return time.Now().Zone() } // Override in tests
+	platformZoneFn = platformTimeZoneName                              // Windows registry lookup; "" elsewhere
 )
 
 // CurrentTimeCollector collects the current time
@@ -26,9 +28,34 @@ func NewCurrentTimeCollector() *CurrentTimeCollector {
 	}
 }
 
+// timeFormat is "24h" (default) or "12h"; set via SetTimeFormat from main
+// once the YAML config is loaded.
+var (
+	timeFormat   = "24h"
+	timeFormatMu sync.RWMutex
+)
+
+// SetTimeFormat sets the clock format from format.timeFormat
+// (config.GetTimeFormat). Thread-safe.
+func SetTimeFormat(format string) {
+	timeFormatMu.Lock()
+	defer timeFormatMu.Unlock()
+	timeFormat = format
+}
+
+// clockLayout returns the time.Format layout for the configured format.
+func clockLayout() string {
+	timeFormatMu.RLock()
+	defer timeFormatMu.RUnlock()
+	if timeFormat == "12h" {
+		return "2006-01-02 03:04 PM"
+	}
+	return "2006-01-02 15:04"
+}
+
 // Collect returns the current time
 func (c *CurrentTimeCollector) Collect(input interface{}, summary interface{}) (string, error) {
-	return fmt.Sprintf("🕐 %s", time.Now().Format("2006-01-02 15:04")), nil
+	return fmt.Sprintf("🕐 %s", nowFn().Format(clockLayout())), nil
 }
 
 // getLocalTimeZoneName attempts to get the IANA timezone name.
@@ -37,8 +64,10 @@ func (c *CurrentTimeCollector) Collect(input interface{}, summary interface{}) (
 //  1. $TZ env var (stripping the leading ":" some systems use)
 //  2. /etc/localtime symlink target — pulls the IANA name out of the path
 //     (e.g. /usr/share/zoneinfo/Asia/Shanghai → "Asia/Shanghai")
-//  3. UTC if the system reports zero offset
-//  4. Synthetic "UTC±H[:MM]" string from the runtime offset as a last resort
+//  3. On Windows, the registry's TimeZoneKeyName mapped to its IANA name
+//     (e.g. "China Standard Time" → "Asia/Shanghai"), for common zones
+//  4. UTC if the system reports zero offset
+//  5. Synthetic "UTC±H[:MM]" string from the runtime offset as a last resort
 //
 // The renderer uses this as a tooltip / debug hint, not as a primary signal,
 // so we never error out — even an unhelpful "UTC+8" is better than nothing.
@@ -53,6 +82,10 @@ func getLocalTimeZoneName() string {
 		}
 	}
 
+	if name := platformZoneFn(); name != "" {
+		return name
+	}
+
 	_, zoneOffset := timeZoneFn()
 	if zoneOffset == 0 {
 		return "UTC"
@@ -192,3 +192,49 @@ func TestGetLocalTimeZoneName(t *testing.T) {
 		})
 	}
 }
+
+func TestCurrentTimeCollector_TimeFormat(t *testing.T) {
+	origNow := nowFn
+	nowFn = func() time.Time { return time.Date(2026, 3, 14, 15, 4, 0, 0, time.UTC) }
+	t.Cleanup(func() {
+		nowFn = origNow
+		SetTimeFormat("24h")
+	})
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"24h", "🕐 2026-03-14 15:04"},
+		{"12h", "🕐 2026-03-14 03:04 PM"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			// Arrange
+			SetTimeFormat(tt.format)
+
+			// Act
+			got, err := NewCurrentTimeCollector().Collect(nil, nil)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCurrentTimeCollector_TimeFormat12hMorning(t *testing.T) {
+	origNow := nowFn
+	nowFn = func() time.Time { return time.Date(2026, 3, 14, 9, 5, 0, 0, time.UTC) }
+	t.Cleanup(func() {
+		nowFn = origNow
+		SetTimeFormat("24h")
+	})
+	SetTimeFormat("12h")
+
+	got, err := NewCurrentTimeCollector().Collect(nil, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "🕐 2026-03-14 09:05 AM", got)
+}
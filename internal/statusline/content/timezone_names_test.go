@@ -0,0 +1,69 @@
+package content
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWindowsZoneToIANA(t *testing.T) {
+	tests := []struct {
+		windows string
+		want    string
+	}{
+		{"China Standard Time", "Asia/Shanghai"},
+		{"Tokyo Standard Time", "Asia/Tokyo"},
+		{"Pacific Standard Time", "America/Los_Angeles"},
+		{"W. Europe Standard Time", "Europe/Berlin"},
+		{"UTC", "UTC"},
+		{"Chatham Islands Standard Time", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.windows, func(t *testing.T) {
+			// Act
+			got := windowsZoneToIANA(tt.windows)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestWindowsZoneNames_AreValidIANA guards the table against typos: every
+// target must load from the Go time zone database.
+func TestWindowsZoneNames_AreValidIANA(t *testing.T) {
+	for windows, iana := range windowsZoneNames {
+		_, err := time.LoadLocation(iana)
+		assert.NoError(t, err, "%q → %q", windows, iana)
+	}
+}
+
+func TestGetLocalTimeZoneName_PlatformZone(t *testing.T) {
+	// Arrange — no TZ, no /etc/localtime, platform lookup knows the zone
+	originalTZ := os.Getenv("TZ")
+	defer os.Setenv("TZ", originalTZ)
+	os.Setenv("TZ", "")
+
+	oldRL := readlinkFn
+	readlinkFn = func(name string) (string, error) { return "", fmt.Errorf("not found") }
+	defer func() { readlinkFn = oldRL }()
+
+	oldPZ := platformZoneFn
+	platformZoneFn = func() string { return "Asia/Shanghai" }
+	defer func() { platformZoneFn = oldPZ }()
+
+	oldTZ := timeZoneFn
+	timeZoneFn = func() (string, int) { return "CST", 8 * 3600 }
+	defer func() { timeZoneFn = oldTZ }()
+
+	// Act
+	got := getLocalTimeZoneName()
+
+	// Assert: the IANA name wins over the "UTC+8" fallback
+	assert.Equal(t, "Asia/Shanghai", got)
+}
@@ -0,0 +1,9 @@
+//go:build !windows
+
+package content
+
+// platformTimeZoneName has nothing to add outside Windows: TZ and the
+// /etc/localtime symlink already cover Unix-like systems.
+func platformTimeZoneName() string {
+	return ""
+}
@@ -0,0 +1,13 @@
+//go:build !windows
+
+package content
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlatformTimeZoneName_NonWindows(t *testing.T) {
+	assert.Empty(t, platformTimeZoneName())
+}
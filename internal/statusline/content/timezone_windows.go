@@ -0,0 +1,54 @@
+//go:build windows
+
+package content
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+const timeZoneInfoKey = `SYSTEM\CurrentControlSet\Control\TimeZoneInformation`
+
+// readTimeZoneKeyNameFn reads the Windows zone name from the registry.
+// Tests replace it to avoid depending on the host's time zone.
+var readTimeZoneKeyNameFn = readTimeZoneKeyName
+
+// platformTimeZoneName returns the IANA name for the zone configured in
+// Windows, or "" when the registry can't be read or the zone isn't mapped.
+func platformTimeZoneName() string {
+	name, err := readTimeZoneKeyNameFn()
+	if err != nil {
+		return ""
+	}
+	return windowsZoneToIANA(name)
+}
+
+// readTimeZoneKeyName returns HKLM\...\TimeZoneInformation\TimeZoneKeyName,
+// e.g. "China Standard Time".
+func readTimeZoneKeyName() (string, error) {
+	path, err := syscall.UTF16PtrFromString(timeZoneInfoKey)
+	if err != nil {
+		return "", err
+	}
+	var key syscall.Handle
+	if err := syscall.RegOpenKeyEx(syscall.HKEY_LOCAL_MACHINE, path, 0, syscall.KEY_READ, &key); err != nil {
+		return "", err
+	}
+	defer syscall.RegCloseKey(key)
+
+	value, err := syscall.UTF16PtrFromString("TimeZoneKeyName")
+	if err != nil {
+		return "", err
+	}
+	buf := make([]uint16, 128)
+	size := uint32(len(buf) * 2)
+	var valueType uint32
+	if err := syscall.RegQueryValueEx(key, value, nil, &valueType, (*byte)(unsafe.Pointer(&buf[0])), &size); err != nil {
+		return "", err
+	}
+	if valueType != syscall.REG_SZ {
+		return "", fmt.Errorf("TimeZoneKeyName has registry type %d, want REG_SZ", valueType)
+	}
+	return syscall.UTF16ToString(buf[:size/2]), nil
+}
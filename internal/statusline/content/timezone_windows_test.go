@@ -0,0 +1,47 @@
+//go:build windows
+
+package content
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlatformTimeZoneName(t *testing.T) {
+	tests := []struct {
+		name    string
+		keyName string
+		err     error
+		want    string
+	}{
+		{"mapped zone", "China Standard Time", nil, "Asia/Shanghai"},
+		{"unmapped zone", "Chatham Islands Standard Time", nil, ""},
+		{"registry error", "", fmt.Errorf("access denied"), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			old := readTimeZoneKeyNameFn
+			readTimeZoneKeyNameFn = func() (string, error) { return tt.keyName, tt.err }
+			t.Cleanup(func() { readTimeZoneKeyNameFn = old })
+
+			// Act
+			got := platformTimeZoneName()
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReadTimeZoneKeyName_ReadsRegistry(t *testing.T) {
+	// Act
+	name, err := readTimeZoneKeyName()
+
+	// Assert: every Windows install has a configured zone
+	assert.NoError(t, err)
+	assert.NotEmpty(t, name)
+}
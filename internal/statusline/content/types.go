@@ -30,6 +30,12 @@ const (
 	ContentParentMemory     ContentType = "parent-memory"
 	ContentSessionTotal     ContentType = "session-total"
 	ContentModeFlags        ContentType = "mode-flags"
+	ContentDataAge          ContentType = "data-age"
+	ContentCost             ContentType = "cost"
+	ContentApproval         ContentType = "approval"
+	ContentTurns            ContentType = "turns"
+	ContentOutputStyle      ContentType = "output-style"
+	ContentQuotaDual        ContentType = "quota-dual"
 )
 
 // Content represents a content fragment
@@ -1,14 +1,12 @@
 package layout
 
-import "github.com/mattn/go-runewidth"
-
 // DefaultLayout returns the default 4x4 grid layout
 // Uses composed content types for compact display
 // Grid structure:
 //
-//	Row 0: Folder | Token (composed: model+token-bar+token-info) | Version
+//	Row 0: Folder | Token (composed: model+token-bar+token-info) | Version | Pending approval, Output style, Data age (debug)
 //	Row 1: Git (composed: branch+status+remote) | Memory-files | Skills
-//	Row 2: Time-Quota | Agent | Todo
+//	Row 2: Time-Quota | Agent | Todo, Turns
 //	Row 3: Tool status detail (unaligned, per-tool ✓/✖ breakdown)
 func DefaultLayout() *Layout {
 	return &Layout{
@@ -17,6 +15,9 @@ func DefaultLayout() *Layout {
 			{ContentType: "folder", Position: Position{Row: 0, Col: 0}, Optional: false},
 			{ContentType: "token", Position: Position{Row: 0, Col: 1}, Optional: false},
 			{ContentType: "claude-version", Position: Position{Row: 0, Col: 2}, Optional: true},
+			{ContentType: "approval", Position: Position{Row: 0, Col: 3}, Optional: true},
+			{ContentType: "output-style", Position: Position{Row: 0, Col: 3}, Optional: true},
+			{ContentType: "data-age", Position: Position{Row: 0, Col: 3}, Optional: true},
 
 			// Row 1
 			{ContentType: "git", Position: Position{Row: 1, Col: 0}, Optional: false},
@@ -26,6 +27,7 @@ func DefaultLayout() *Layout {
 			{ContentType: "time-quota", Position: Position{Row: 2, Col: 0}, Optional: false},
 			{ContentType: "agent", Position: Position{Row: 2, Col: 1}, Optional: true},
 			{ContentType: "todo", Position: Position{Row: 2, Col: 2}, Optional: true},
+			{ContentType: "turns", Position: Position{Row: 2, Col: 2}, Optional: true},
 			{ContentType: "parent-memory", Position: Position{Row: 2, Col: 3}, Optional: true},
 
 			// Row 3: per-tool status detail, full-width, NOT column-aligned
@@ -93,8 +95,9 @@ func (g *Grid) calculateWidths() {
 		maxWidth := 0
 		for row := 0; row < 4; row++ {
 			content := g.Rows[row].Cells[col]
-			// Use runewidth to correctly handle emoji and wide characters
-			width := runewidth.StringWidth(content)
+			// Visible width: handles emoji / wide characters and ignores the
+			// ANSI colour codes embedded by coloured segments
+			width := displayWidth(content)
 			if width > maxWidth {
 				maxWidth = width
 			}
@@ -7,14 +7,14 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-// TestDefaultLayout verifies DefaultLayout returns a layout with 10 cells in expected positions.
+// TestDefaultLayout verifies DefaultLayout returns a layout with 15 cells in expected positions.
 func TestDefaultLayout(t *testing.T) {
 	// Act
 	layout := DefaultLayout()
 
 	// Assert
 	require.NotNil(t, layout)
-	assert.Equal(t, 11, len(layout.Cells), "default layout should have 11 cells")
+	assert.Equal(t, 15, len(layout.Cells), "default layout should have 15 cells")
 
 	expectedCells := []struct {
 		contentType string
@@ -25,12 +25,16 @@ func TestDefaultLayout(t *testing.T) {
 		{"folder", 0, 0, false, false},
 		{"token", 0, 1, false, false},
 		{"claude-version", 0, 2, true, false},
+		{"approval", 0, 3, true, false},
+		{"output-style", 0, 3, true, false},
+		{"data-age", 0, 3, true, false},
 		{"git", 1, 0, false, false},
 		{"memory-files", 1, 1, true, false},
 		{"session-total", 1, 2, true, false},
 		{"time-quota", 2, 0, false, false},
 		{"agent", 2, 1, true, false},
 		{"todo", 2, 2, true, false},
+		{"turns", 2, 2, true, false},
 		{"parent-memory", 2, 3, true, false},
 		{"tool-status-detail", 3, 0, true, true},
 	}
@@ -339,6 +343,16 @@ func TestCalculateWidths(t *testing.T) {
 			},
 			wantColWidths: []int{3, 5, 0, 0},
 		},
+		{
+			name: "ANSI colour codes are not counted",
+			rows: []GridRow{
+				{Cells: []string{"\x1b[32m+5\x1b[0m \x1b[33m~3\x1b[0m", "", "", ""}},
+				{Cells: []string{"", "", "", ""}},
+				{Cells: []string{"", "", "", ""}},
+				{Cells: []string{"", "", "", ""}},
+			},
+			wantColWidths: []int{5, 0, 0, 0},
+		},
 	}
 
 	for _, tt := range tests {
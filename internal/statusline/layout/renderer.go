@@ -10,6 +10,11 @@ import (
 // ansiRegex matches ANSI escape sequences (color codes, etc.)
 var ansiRegex = regexp.MustCompile(`\x1b\[[0-9;]*m`)
 
+// StripANSI removes ANSI escape sequences, leaving only the visible text.
+func StripANSI(s string) string {
+	return ansiRegex.ReplaceAllString(s, "")
+}
+
 // UseNarrowBlockWidth controls whether Block Elements (█░▓▒ etc.)
 // should be treated as width 1 for consistent rendering.
 // This is needed for terminals like VSCode/WARP that render ALL
@@ -25,7 +30,7 @@ func isBlockElement(r rune) bool {
 // When UseNarrowBlockWidth is true, Block Elements are treated as width 1.
 func displayWidth(s string) int {
 	// Strip ANSI codes first
-	s = ansiRegex.ReplaceAllString(s, "")
+	s = StripANSI(s)
 
 	if !UseNarrowBlockWidth {
 		return runewidth.StringWidth(s)
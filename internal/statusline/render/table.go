@@ -5,17 +5,32 @@ package render
 import (
 	"strings"
 
+	"github.com/young1lin/claude-token-monitor/internal/statusline/config"
 	"github.com/young1lin/claude-token-monitor/internal/statusline/layout"
 )
 
+// DefaultSeparator joins rows in single-line mode unless display.separator
+// overrides it.
+const DefaultSeparator = config.DefaultSeparator
+
 // TableRenderer renders a layout grid to output lines
 type TableRenderer struct {
-	grid *layout.Grid
+	grid      *layout.Grid
+	separator string
 }
 
 // NewTableRenderer creates a new table renderer
 func NewTableRenderer(grid *layout.Grid) *TableRenderer {
-	return &TableRenderer{grid: grid}
+	return &TableRenderer{grid: grid, separator: DefaultSeparator}
+}
+
+// SetSeparator sets the single-line row separator. An empty string keeps
+// DefaultSeparator rather than running rows together.
+func (t *TableRenderer) SetSeparator(sep string) {
+	if sep == "" {
+		sep = DefaultSeparator
+	}
+	t.separator = sep
 }
 
 // Render renders the grid to a slice of output lines
@@ -30,5 +45,5 @@ func (t *TableRenderer) RenderSingleLine() string {
 	if len(lines) == 0 {
 		return ""
 	}
-	return strings.Join(lines, " | ")
+	return strings.Join(lines, t.separator)
 }
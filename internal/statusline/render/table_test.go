@@ -199,6 +199,40 @@ func TestRenderSingleLine(t *testing.T) {
 		assert.Contains(t, result, "10K/100K")
 	})
 
+	t.Run("custom separator joins rows", func(t *testing.T) {
+		// Arrange
+		contentMap := layout.CellContent{
+			"folder": "sep-test",
+			"git":    "main",
+		}
+		grid := layout.NewGrid(layout.DefaultLayout(), contentMap)
+		tr := NewTableRenderer(grid)
+		tr.SetSeparator(" · ")
+
+		// Act
+		result := tr.RenderSingleLine()
+
+		// Assert
+		assert.Equal(t, strings.Join(tr.Render(), " · "), result)
+	})
+
+	t.Run("empty separator keeps the default", func(t *testing.T) {
+		// Arrange
+		contentMap := layout.CellContent{
+			"folder": "sep-test",
+			"git":    "main",
+		}
+		grid := layout.NewGrid(layout.DefaultLayout(), contentMap)
+		tr := NewTableRenderer(grid)
+		tr.SetSeparator("")
+
+		// Act
+		result := tr.RenderSingleLine()
+
+		// Assert
+		assert.Equal(t, strings.Join(tr.Render(), DefaultSeparator), result)
+	})
+
 	t.Run("empty grid returns empty string", func(t *testing.T) {
 		// Arrange
 		contentMap := layout.CellContent{}
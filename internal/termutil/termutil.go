@@ -0,0 +1,34 @@
+// Package termutil holds small helpers for sizing terminal output that are
+// shared across renderers.
+package termutil
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// BarWidthEnvVar overrides the width, in cells, of the context progress bar.
+const BarWidthEnvVar = "STATUSLINE_BAR_WIDTH"
+
+// GetBarWidth reads a bar width from the environment variable envKey.
+// Unset, empty or non-numeric values yield defaultWidth; anything else is
+// clamped to [minWidth, maxWidth] so a typo can't produce a zero-width or
+// line-wrapping bar.
+func GetBarWidth(envKey string, defaultWidth, minWidth, maxWidth int) int {
+	raw := strings.TrimSpace(os.Getenv(envKey))
+	if raw == "" {
+		return defaultWidth
+	}
+	width, err := strconv.Atoi(raw)
+	if err != nil {
+		return defaultWidth
+	}
+	if width < minWidth {
+		return minWidth
+	}
+	if width > maxWidth {
+		return maxWidth
+	}
+	return width
+}
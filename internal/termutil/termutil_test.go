@@ -0,0 +1,36 @@
+package termutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBarWidth(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  int
+	}{
+		{"unset uses default", "", 10},
+		{"valid value", "25", 25},
+		{"whitespace trimmed", " 12 ", 12},
+		{"below min clamps", "1", 4},
+		{"above max clamps", "200", 40},
+		{"negative clamps to min", "-5", 4},
+		{"non-numeric uses default", "wide", 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			t.Setenv(BarWidthEnvVar, tt.value)
+
+			// Act
+			got := GetBarWidth(BarWidthEnvVar, 10, 4, 40)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
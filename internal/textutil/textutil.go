@@ -0,0 +1,56 @@
+// Package textutil holds rune-aware text helpers shared by the parser and
+// the statusline collectors.
+package textutil
+
+import (
+	"sync"
+	"unicode/utf8"
+)
+
+// DefaultEllipsis is appended to truncated text unless format.ellipsis
+// overrides it.
+const DefaultEllipsis = ".."
+
+// ellipsis is the configured truncation marker; set via SetEllipsis from
+// main once the YAML config is loaded.
+var (
+	ellipsis   = DefaultEllipsis
+	ellipsisMu sync.RWMutex
+)
+
+// SetEllipsis sets the truncation marker. An empty string restores
+// DefaultEllipsis. Thread-safe.
+func SetEllipsis(s string) {
+	if s == "" {
+		s = DefaultEllipsis
+	}
+	ellipsisMu.Lock()
+	defer ellipsisMu.Unlock()
+	ellipsis = s
+}
+
+// Ellipsis returns the configured truncation marker.
+func Ellipsis() string {
+	ellipsisMu.RLock()
+	defer ellipsisMu.RUnlock()
+	return ellipsis
+}
+
+// Truncate shortens s to at most limit runes, counting runes rather than bytes
+// so multi-byte text is never cut mid-character. Truncated text keeps
+// limit-3 runes plus the ellipsis, so "…", ".." and "..." all fit the same
+// budget; a longer custom ellipsis eats into the kept text instead.
+func Truncate(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+	marker := Ellipsis()
+	keep := limit - 3
+	if n := utf8.RuneCountInString(marker); n > 3 {
+		keep = limit - n
+	}
+	if keep < 0 {
+		keep = 0
+	}
+	return string([]rune(s)[:keep]) + marker
+}
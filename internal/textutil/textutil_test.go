@@ -0,0 +1,41 @@
+package textutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncate(t *testing.T) {
+	t.Cleanup(func() { SetEllipsis(DefaultEllipsis) })
+
+	tests := []struct {
+		name     string
+		ellipsis string
+		s        string
+		max      int
+		want     string
+	}{
+		{"fits unchanged", "", "short", 20, "short"},
+		{"exactly max unchanged", "", "abcdefghij", 10, "abcdefghij"},
+		{"default ellipsis", "", "abcdefghijklmnopqrstuvwxyz", 20, "abcdefghijklmnopq.."},
+		{"single-char ellipsis", "…", "abcdefghijklmnopqrstuvwxyz", 20, "abcdefghijklmnopq…"},
+		{"three-dot ellipsis", "...", "abcdefghijklmnopqrstuvwxyz", 20, "abcdefghijklmnopq..."},
+		{"long custom ellipsis stays within max", "[more]", "abcdefghijklmnopqrstuvwxyz", 10, "abcd[more]"},
+		{"empty restores default", "", "abcdefghijkl", 10, "abcdefg.."},
+		{"multi-byte runes are not split", "", "我的中文项目名称很长很长的", 8, "我的中文项.."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			SetEllipsis(tt.ellipsis)
+
+			// Act
+			got := Truncate(tt.s, tt.max)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
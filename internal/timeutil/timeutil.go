@@ -0,0 +1,33 @@
+// Package timeutil holds time formatting helpers shared across renderers.
+package timeutil
+
+import (
+	"fmt"
+	"time"
+)
+
+// FormatDuration formats a duration compactly: "42s", "5m", "1h30m", and
+// past 24h "1d 1h". Sub-minute durations are rounded to the nearest second
+// so a 0.6s session reads "1s" rather than "0s". When capDays is positive,
+// durations beyond it collapse to "<capDays>d+".
+func FormatDuration(d time.Duration, capDays int) string {
+	if d < time.Minute {
+		d = d.Round(time.Second)
+	}
+
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d <= 24*time.Hour:
+		h := int(d.Hours())
+		m := int(d.Minutes()) % 60
+		return fmt.Sprintf("%dh%dm", h, m)
+	}
+
+	if capDays > 0 && d > time.Duration(capDays)*24*time.Hour {
+		return fmt.Sprintf("%dd+", capDays)
+	}
+	return fmt.Sprintf("%dd %dh", int(d.Hours())/24, int(d.Hours())%24)
+}
@@ -0,0 +1,42 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		d       time.Duration
+		capDays int
+		want    string
+	}{
+		{name: "0s", d: 0, want: "0s"},
+		{name: "sub-second rounds up", d: 600 * time.Millisecond, want: "1s"},
+		{name: "sub-second rounds down", d: 400 * time.Millisecond, want: "0s"},
+		{name: "9.7s rounds to 10s", d: 9700 * time.Millisecond, want: "10s"},
+		{name: "59s", d: 59 * time.Second, want: "59s"},
+		{name: "59.6s rounds to a minute", d: 59600 * time.Millisecond, want: "1m"},
+		{name: "60s", d: 60 * time.Second, want: "1m"},
+		{name: "61s", d: 61 * time.Second, want: "1m"},
+		{name: "3599s", d: 3599 * time.Second, want: "59m"},
+		{name: "3600s", d: 3600 * time.Second, want: "1h0m"},
+		{name: "24h", d: 24 * time.Hour, want: "24h0m"},
+		{name: "25h switches to days", d: 25 * time.Hour, want: "1d 1h"},
+		{name: "past cap collapses", d: 49 * time.Hour, capDays: 2, want: "2d+"},
+		{name: "at cap shows days", d: 48 * time.Hour, capDays: 2, want: "2d 0h"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := FormatDuration(tt.d, tt.capDays)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}